@@ -0,0 +1,249 @@
+// Package certificates parses X.509 certificates from PEM and DER files,
+// Java key stores and PKCS#12 key stores, which are used by destinations
+// and Cloud Connector
+package certificates
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"time"
+
+	pkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+// Supported formats of certificate files
+const (
+	FormatPEM    = "PEM"
+	FormatDER    = "DER"
+	FormatJKS    = "JKS"
+	FormatPKCS12 = "PKCS12"
+)
+
+// ErrPasswordRequired returned for PKCS#12 key stores, which certificates
+// can not be read without password
+var ErrPasswordRequired = errors.New("certificates of password-protected PKCS#12 key store can not be read without password")
+
+// ErrInvalidPassword returned for PKCS#12 key stores, which do not match
+// provided password
+var ErrInvalidPassword = errors.New("invalid password of PKCS#12 key store")
+
+const (
+	jksMagic           = 0xFEEDFEED
+	jceksMagic         = 0xCECECECE
+	jksPrivateKeyTag   = 1
+	jksTrustedCertTag  = 2
+	jksMaxEntriesCount = 10000
+)
+
+// DetectFormat detects format of certificate file by its content and,
+// if content is ambiguous, by file extension
+func DetectFormat(name string, content []byte) string {
+	if len(content) >= 4 {
+		magic := binary.BigEndian.Uint32(content)
+		if magic == jksMagic || magic == jceksMagic {
+			return FormatJKS
+		}
+	}
+	if bytes.Contains(content, []byte("-----BEGIN ")) {
+		return FormatPEM
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".p12", ".pfx":
+		return FormatPKCS12
+	case ".jks", ".keystore", ".truststore":
+		return FormatJKS
+	case ".pem", ".crt", ".cer", ".key":
+		if _, err := x509.ParseCertificates(content); err == nil {
+			return FormatDER
+		}
+		return FormatPEM
+	}
+	return FormatDER
+}
+
+// Parse parses certificates of certificate file. Private keys are ignored
+func Parse(name string, content []byte) ([]*x509.Certificate, error) {
+	return ParseWithPassword(name, content, "")
+}
+
+// ParseWithPassword parses certificates of certificate file. Password is
+// used only for PKCS#12 key stores, which are tried with empty password,
+// if password is not provided. Private keys are ignored
+func ParseWithPassword(name string, content []byte, password string) ([]*x509.Certificate, error) {
+	switch DetectFormat(name, content) {
+	case FormatPEM:
+		return parsePEM(content)
+	case FormatJKS:
+		return parseJKS(content)
+	case FormatPKCS12:
+		return parsePKCS12(content, password)
+	}
+	return x509.ParseCertificates(content)
+}
+
+// parsePEM parses all CERTIFICATE blocks of PEM file
+func parsePEM(content []byte) ([]*x509.Certificate, error) {
+	certificates := make([]*x509.Certificate, 0)
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificates found in PEM file")
+	}
+	return certificates, nil
+}
+
+// parsePKCS12 parses certificates of PKCS#12 key store with private key and
+// certificate chain or of PKCS#12 trust store. Password is checked against
+// integrity MAC of key store
+func parsePKCS12(content []byte, password string) ([]*x509.Certificate, error) {
+	_, certificate, caCertificates, err := pkcs12.DecodeChain(content, password)
+	if err == nil {
+		return append([]*x509.Certificate{certificate}, caCertificates...), nil
+	}
+	if err == pkcs12.ErrIncorrectPassword {
+		if password == "" {
+			return nil, ErrPasswordRequired
+		}
+		return nil, ErrInvalidPassword
+	}
+	certificates, trustStoreErr := pkcs12.DecodeTrustStore(content, password)
+	if trustStoreErr != nil {
+		return nil, fmt.Errorf("invalid PKCS#12 key store: %s", err.Error())
+	}
+	return certificates, nil
+}
+
+// parseJKS parses certificates of private key and trusted certificate
+// entries of Java key store. Certificates are not encrypted in key store,
+// so password is not needed
+func parseJKS(content []byte) ([]*x509.Certificate, error) {
+	reader := &jksReader{data: content}
+	magic := reader.readUint32()
+	version := reader.readUint32()
+	count := reader.readUint32()
+	if reader.err != nil || (magic != jksMagic && magic != jceksMagic) {
+		return nil, errors.New("invalid Java key store")
+	}
+	if version != 1 && version != 2 {
+		return nil, fmt.Errorf("unsupported Java key store version %d", version)
+	}
+	if count > jksMaxEntriesCount {
+		return nil, fmt.Errorf("invalid number of Java key store entries %d", count)
+	}
+
+	certificates := make([]*x509.Certificate, 0)
+	for i := uint32(0); i < count && reader.err == nil; i++ {
+		tag := reader.readUint32()
+		reader.readString() // alias
+		reader.readUint64() // timestamp
+		switch tag {
+		case jksPrivateKeyTag:
+			reader.readBytes() // encrypted private key
+			chainLength := reader.readUint32()
+			for j := uint32(0); j < chainLength && reader.err == nil; j++ {
+				certificate, err := reader.readCertificate(version)
+				if err != nil {
+					return nil, err
+				}
+				certificates = append(certificates, certificate)
+			}
+		case jksTrustedCertTag:
+			certificate, err := reader.readCertificate(version)
+			if err != nil {
+				return nil, err
+			}
+			certificates = append(certificates, certificate)
+		default:
+			// Secret key entries of JCEKS key stores are serialized Java
+			// objects, so the rest of key store can not be read
+			return certificates, nil
+		}
+	}
+	if reader.err != nil {
+		return nil, fmt.Errorf("invalid Java key store: %s", reader.err.Error())
+	}
+	return certificates, nil
+}
+
+// jksReader reader of Java key store binary format. First error stops
+// reading and is kept in err
+type jksReader struct {
+	data []byte
+	err  error
+}
+
+func (r *jksReader) read(length int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if length < 0 || length > len(r.data) {
+		r.err = errors.New("unexpected end of data")
+		return nil
+	}
+	value := r.data[:length]
+	r.data = r.data[length:]
+	return value
+}
+
+func (r *jksReader) readUint32() uint32 {
+	if value := r.read(4); value != nil {
+		return binary.BigEndian.Uint32(value)
+	}
+	return 0
+}
+
+func (r *jksReader) readUint64() uint64 {
+	if value := r.read(8); value != nil {
+		return binary.BigEndian.Uint64(value)
+	}
+	return 0
+}
+
+func (r *jksReader) readString() string {
+	var length uint16
+	if value := r.read(2); value != nil {
+		length = binary.BigEndian.Uint16(value)
+	}
+	return string(r.read(int(length)))
+}
+
+func (r *jksReader) readBytes() []byte {
+	return r.read(int(r.readUint32()))
+}
+
+func (r *jksReader) readCertificate(version uint32) (*x509.Certificate, error) {
+	if version == 2 {
+		r.readString() // certificate type, e.g. X.509
+	}
+	data := r.readBytes()
+	if r.err != nil {
+		return nil, r.err
+	}
+	return x509.ParseCertificate(data)
+}
+
+// DaysUntilExpiry returns number of days until certificate expires.
+// Negative number of days is returned for expired certificates
+func DaysUntilExpiry(certificate *x509.Certificate, now time.Time) int {
+	return int(math.Floor(certificate.NotAfter.Sub(now).Hours() / 24))
+}
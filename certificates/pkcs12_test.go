@@ -0,0 +1,73 @@
+package certificates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePKCS12(t *testing.T) {
+	testCases := []struct {
+		file     string
+		password string
+		subjects []string
+		err      error
+	}{
+		{file: "aes256.p12", password: "secret", subjects: []string{"CN=client", "CN=ca"}},
+		{file: "rc2.p12", password: "secret", subjects: []string{"CN=client", "CN=ca"}},
+		{file: "3des.p12", password: "secret", subjects: []string{"CN=client"}},
+		{file: "aes128.p12", password: "sécret", subjects: []string{"CN=client"}},
+		{file: "unencrypted.p12", password: "secret", subjects: []string{"CN=client"}},
+		{file: "empty-password.p12", subjects: []string{"CN=client"}},
+		{file: "truststore.p12", password: "secret", subjects: []string{"CN=client", "CN=ca"}},
+		{file: "aes256.p12", err: ErrPasswordRequired},
+		{file: "aes256.p12", password: "wrong", err: ErrInvalidPassword},
+		{file: "rc2.p12", password: "wrong", err: ErrInvalidPassword},
+		{file: "unencrypted.p12", err: ErrPasswordRequired},
+		{file: "truststore.p12", password: "wrong", err: ErrInvalidPassword},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.file+"/"+tc.password, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("testdata", tc.file))
+			if err != nil {
+				t.Fatalf("could not read key store: %s", err)
+			}
+			if format := DetectFormat(tc.file, content); format != FormatPKCS12 {
+				t.Fatalf("format is %s, expected %s", format, FormatPKCS12)
+			}
+			certificates, err := ParseWithPassword(tc.file, content, tc.password)
+			if tc.err != nil {
+				if err != tc.err {
+					t.Fatalf("error is %v, expected %v", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not parse key store: %s", err)
+			}
+			subjects := make([]string, 0, len(certificates))
+			for _, certificate := range certificates {
+				subjects = append(subjects, certificate.Subject.String())
+			}
+			if len(subjects) != len(tc.subjects) {
+				t.Fatalf("subjects are %v, expected %v", subjects, tc.subjects)
+			}
+			for _, subject := range tc.subjects {
+				found := false
+				for _, actual := range subjects {
+					found = found || actual == subject
+				}
+				if !found {
+					t.Errorf("subjects are %v, expected %v", subjects, tc.subjects)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePKCS12Invalid(t *testing.T) {
+	if _, err := ParseWithPassword("invalid.p12", []byte("not a key store"), "secret"); err == nil {
+		t.Errorf("expected error for invalid key store")
+	}
+}
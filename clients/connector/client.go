@@ -0,0 +1,137 @@
+// Package connector implements client of Cloud Connector administration
+// REST API. Requests are authenticated with basic authentication of
+// Cloud Connector administrator
+package connector
+
+import (
+	"bytes"
+	"cf-cloud-connector/clients"
+	models "cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client client of Cloud Connector administration REST API
+type Client struct {
+	URL        string
+	User       string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// Error failed request of Cloud Connector REST API
+type Error struct {
+	StatusCode int
+	message    string
+}
+
+// Error returns error message
+func (e *Error) Error() string {
+	return e.message
+}
+
+// IsNotFound checks if error is returned for entity, which does not exist
+// or is not configured in Cloud Connector
+func IsNotFound(err error) bool {
+	connectorError, ok := err.(*Error)
+	return ok && connectorError.StatusCode == http.StatusNotFound
+}
+
+// NewClient creates client of Cloud Connector with URL, e.g.
+// https://localhost:8443. Custom CA is trusted in addition to system
+// certificates, if path is provided
+func NewClient(connectorURL string, user string, password string, trustInsecure bool, customCAPath string) (*Client, error) {
+	parsedURL, err := url.Parse(connectorURL)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid Cloud Connector URL %q", connectorURL)
+	}
+	httpClient, err := clients.GetClient(trustInsecure, customCAPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		URL:        strings.TrimSuffix(connectorURL, "/"),
+		User:       user,
+		Password:   password,
+		HTTPClient: httpClient,
+	}, nil
+}
+
+// do sends request with JSON payload to path of Cloud Connector API and
+// parses JSON response into result, if provided
+func (c *Client) do(method string, path string, payload interface{}, result interface{}, action string) error {
+	var requestBody io.Reader
+	requestURL := c.URL + "/api/v1" + path
+
+	log.Tracef("Making request to: %s\n", requestURL)
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(data)
+	}
+	request, err := http.NewRequest(method, requestURL, requestBody)
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(c.User, c.Password)
+	request.Header.Add("Accept", "application/json")
+	if payload != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	// Get response body
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	log.Trace(log.Response{Head: response, Body: body})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return newConnectorError(action, response, body)
+	}
+
+	// Parse response JSON
+	if result != nil && len(body) > 0 {
+		return json.Unmarshal(body, result)
+	}
+	return nil
+}
+
+// newConnectorError creates error for failed Cloud Connector request,
+// using error message returned by Cloud Connector, if available
+func newConnectorError(action string, response *http.Response, body []byte) error {
+	details := string(body)
+	var errorResponse models.SCCErrorResponse
+	if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.Message != "" {
+		details = errorResponse.Message
+	} else if response.StatusCode == http.StatusUnauthorized {
+		details = "check user and password of Cloud Connector"
+	}
+	return &Error{
+		StatusCode: response.StatusCode,
+		message:    fmt.Sprintf("could not %s: [%s] %s", action, response.Status, details),
+	}
+}
+
+// subaccountPath returns API path of subaccount
+func subaccountPath(regionHost string, subaccount string) string {
+	return "/configuration/subaccounts/" + url.PathEscape(regionHost) + "/" + url.PathEscape(subaccount)
+}
+
+// systemMappingPath returns API path of system mapping of subaccount
+func systemMappingPath(regionHost string, subaccount string, virtualHost string, virtualPort string) string {
+	return subaccountPath(regionHost, subaccount) + "/systemMappings/" + url.PathEscape(virtualHost+":"+virtualPort)
+}
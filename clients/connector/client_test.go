@@ -0,0 +1,288 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// request request received by test server
+type request struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// newTestClient starts Cloud Connector test server, which responds to
+// requests of method and escaped path with status and body, and returns
+// client of the server and requests it received
+func newTestClient(t *testing.T, responses map[string]func() (int, string)) (*Client, *[]request) {
+	requests := make([]request, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{Method: r.Method, Path: r.URL.EscapedPath(), Body: string(body)})
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Accept") != "application/json" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		response, ok := responses[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"NOT_FOUND","message":"Resource not found"}`))
+			return
+		}
+		status, responseBody := response()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(responseBody))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/", "admin", "secret", true, "")
+	if err != nil {
+		t.Fatalf("could not create client: %s", err)
+	}
+	return client, &requests
+}
+
+// respond returns response with status and body
+func respond(status int, body string) func() (int, string) {
+	return func() (int, string) { return status, body }
+}
+
+func TestNewClientInvalidURL(t *testing.T) {
+	for _, connectorURL := range []string{"", "localhost:8443", "https://"} {
+		if _, err := NewClient(connectorURL, "admin", "secret", false, ""); err == nil {
+			t.Errorf("expected error for URL %q", connectorURL)
+		}
+	}
+}
+
+func TestGetSubaccounts(t *testing.T) {
+	client, requests := newTestClient(t, map[string]func() (int, string){
+		"GET /api/v1/configuration/subaccounts": respond(http.StatusOK,
+			`[{"regionHost":"cf.eu10.hana.ondemand.com","subaccount":"sub1","locationID":"loc1","displayName":"Dev"},
+			  {"regionHost":"cf.us10.hana.ondemand.com","subaccount":"sub2"}]`),
+		"GET /api/v1/configuration/subaccounts/cf.eu10.hana.ondemand.com/sub1": respond(http.StatusOK,
+			`{"regionHost":"cf.eu10.hana.ondemand.com","subaccount":"sub1",
+			  "tunnel":{"state":"Connected","connectedSinceTimeStamp":1700000000000,"connections":2},
+			  "subaccountCertificate":{"subjectDN":"CN=sub1","issuer":"CN=CA","notBeforeTimeStamp":1700000000000,"notAfterTimeStamp":1800000000000}}`),
+	})
+
+	subaccounts, err := client.GetSubaccounts()
+	if err != nil {
+		t.Fatalf("could not get subaccounts: %s", err)
+	}
+	expected := []models.SCCSubaccount{
+		{RegionHost: "cf.eu10.hana.ondemand.com", Subaccount: "sub1", LocationID: "loc1", DisplayName: "Dev"},
+		{RegionHost: "cf.us10.hana.ondemand.com", Subaccount: "sub2"},
+	}
+	if !reflect.DeepEqual(subaccounts, expected) {
+		t.Errorf("subaccounts are %+v, expected %+v", subaccounts, expected)
+	}
+
+	subaccount, err := client.GetSubaccount("cf.eu10.hana.ondemand.com", "sub1")
+	if err != nil {
+		t.Fatalf("could not get subaccount: %s", err)
+	}
+	if subaccount.Tunnel == nil || subaccount.Tunnel.State != models.SCCTunnelConnected || subaccount.Tunnel.Connections != 2 {
+		t.Errorf("tunnel is %+v, expected connected tunnel with 2 connections", subaccount.Tunnel)
+	}
+	if subaccount.SubaccountCertificate == nil || subaccount.SubaccountCertificate.NotAfter().UnixMilli() != 1800000000000 {
+		t.Errorf("subaccount certificate is %+v, expected certificate expiring at 1800000000000", subaccount.SubaccountCertificate)
+	}
+	if len(*requests) != 2 {
+		t.Errorf("%d requests sent, expected 2", len(*requests))
+	}
+}
+
+func TestSystemMappings(t *testing.T) {
+	mappingPath := "/api/v1/configuration/subaccounts/cf.eu10.hana.ondemand.com/sub1/systemMappings"
+	client, requests := newTestClient(t, map[string]func() (int, string){
+		"GET " + mappingPath: respond(http.StatusOK,
+			`[{"virtualHost":"erp","virtualPort":"44300","localHost":"erp.corp","localPort":"443","protocol":"HTTPS","backendType":"abapSys"}]`),
+		"POST " + mappingPath:                  respond(http.StatusCreated, ""),
+		"DELETE " + mappingPath + "/erp:44300": respond(http.StatusNoContent, ""),
+		"GET " + mappingPath + "/erp:44300/resources": respond(http.StatusOK,
+			`[{"id":"/sap/opu/odata","enabled":true,"exactMatchOnly":false},{"id":"/ping","enabled":false,"exactMatchOnly":true}]`),
+		"POST " + mappingPath + "/erp:44300/resources": respond(http.StatusCreated, ""),
+	})
+
+	// Get system mappings
+	mappings, err := client.GetSystemMappings("cf.eu10.hana.ondemand.com", "sub1")
+	if err != nil {
+		t.Fatalf("could not get system mappings: %s", err)
+	}
+	expectedMappings := []models.SCCSystemMapping{{
+		VirtualHost: "erp", VirtualPort: "44300", LocalHost: "erp.corp", LocalPort: "443", Protocol: "HTTPS", BackendType: "abapSys",
+	}}
+	if !reflect.DeepEqual(mappings, expectedMappings) {
+		t.Errorf("system mappings are %+v, expected %+v", mappings, expectedMappings)
+	}
+
+	// Create system mapping
+	if err := client.CreateSystemMapping("cf.eu10.hana.ondemand.com", "sub1", expectedMappings[0]); err != nil {
+		t.Fatalf("could not create system mapping: %s", err)
+	}
+	var createdMapping models.SCCSystemMapping
+	if err := json.Unmarshal([]byte((*requests)[1].Body), &createdMapping); err != nil {
+		t.Fatalf("could not unmarshal created system mapping: %s", err)
+	}
+	if createdMapping != expectedMappings[0] {
+		t.Errorf("created system mapping is %+v, expected %+v", createdMapping, expectedMappings[0])
+	}
+
+	// Get resources
+	resources, err := client.GetResources("cf.eu10.hana.ondemand.com", "sub1", "erp", "44300")
+	if err != nil {
+		t.Fatalf("could not get resources: %s", err)
+	}
+	expectedResources := []models.SCCResource{
+		{ID: "/sap/opu/odata", Enabled: true},
+		{ID: "/ping", ExactMatchOnly: true},
+	}
+	if !reflect.DeepEqual(resources, expectedResources) {
+		t.Errorf("resources are %+v, expected %+v", resources, expectedResources)
+	}
+
+	// Create resource
+	if err := client.CreateResource("cf.eu10.hana.ondemand.com", "sub1", "erp", "44300", expectedResources[0]); err != nil {
+		t.Fatalf("could not create resource: %s", err)
+	}
+	var createdResource models.SCCResource
+	if err := json.Unmarshal([]byte((*requests)[3].Body), &createdResource); err != nil {
+		t.Fatalf("could not unmarshal created resource: %s", err)
+	}
+	if createdResource != expectedResources[0] {
+		t.Errorf("created resource is %+v, expected %+v", createdResource, expectedResources[0])
+	}
+
+	// Delete system mapping
+	if err := client.DeleteSystemMapping("cf.eu10.hana.ondemand.com", "sub1", "erp", "44300"); err != nil {
+		t.Fatalf("could not delete system mapping: %s", err)
+	}
+
+	expectedRequests := []string{
+		"GET " + mappingPath,
+		"POST " + mappingPath,
+		"GET " + mappingPath + "/erp:44300/resources",
+		"POST " + mappingPath + "/erp:44300/resources",
+		"DELETE " + mappingPath + "/erp:44300",
+	}
+	actualRequests := make([]string, 0)
+	for _, r := range *requests {
+		actualRequests = append(actualRequests, r.Method+" "+r.Path)
+	}
+	if !reflect.DeepEqual(actualRequests, expectedRequests) {
+		t.Errorf("requests are %v, expected %v", actualRequests, expectedRequests)
+	}
+}
+
+func TestGetCertificates(t *testing.T) {
+	certificate := `{"subjectDN":"CN=%s","issuer":"CN=CA","serialNumber":"01","notBeforeTimeStamp":1700000000000,"notAfterTimeStamp":1800000000000}`
+	client, _ := newTestClient(t, map[string]func() (int, string){
+		"GET /api/v1/configuration/connector/ui/uiCertificate":            respond(http.StatusOK, fmt.Sprintf(certificate, "ui")),
+		"GET /api/v1/configuration/connector/onPremise/systemCertificate": respond(http.StatusOK, fmt.Sprintf(certificate, "system")),
+		"GET /api/v1/configuration/connector/onPremise/ppCaCertificate":   respond(http.StatusOK, fmt.Sprintf(certificate, "ca")),
+	})
+
+	for name, getCertificate := range map[string]func() (models.SCCCertificate, error){
+		"ui":     client.GetUICertificate,
+		"system": client.GetSystemCertificate,
+		"ca":     client.GetCACertificate,
+	} {
+		result, err := getCertificate()
+		if err != nil {
+			t.Errorf("could not get %s certificate: %s", name, err)
+			continue
+		}
+		expected := models.SCCCertificate{
+			SubjectDN:          "CN=" + name,
+			Issuer:             "CN=CA",
+			SerialNumber:       "01",
+			NotBeforeTimeStamp: 1700000000000,
+			NotAfterTimeStamp:  1800000000000,
+		}
+		if result != expected {
+			t.Errorf("%s certificate is %+v, expected %+v", name, result, expected)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	client, _ := newTestClient(t, map[string]func() (int, string){
+		"GET /api/v1/configuration/subaccounts":                respond(http.StatusInternalServerError, `{"type":"ERROR","message":"Internal error"}`),
+		"GET /api/v1/configuration/connector/ui/uiCertificate": respond(http.StatusBadGateway, "Bad gateway"),
+	})
+
+	testCases := []struct {
+		name     string
+		call     func(client *Client) error
+		status   int
+		notFound bool
+		message  string
+	}{
+		{
+			name:    "message of error response",
+			call:    func(client *Client) error { _, err := client.GetSubaccounts(); return err },
+			status:  http.StatusInternalServerError,
+			message: "could not get subaccounts: [500 Internal Server Error] Internal error",
+		},
+		{
+			name:    "body of non-JSON response",
+			call:    func(client *Client) error { _, err := client.GetUICertificate(); return err },
+			status:  http.StatusBadGateway,
+			message: "could not get UI certificate: [502 Bad Gateway] Bad gateway",
+		},
+		{
+			name:     "not found",
+			call:     func(client *Client) error { _, err := client.GetSystemCertificate(); return err },
+			status:   http.StatusNotFound,
+			notFound: true,
+			message:  "could not get system certificate: [404 Not Found] Resource not found",
+		},
+		{
+			name: "unauthorized",
+			call: func(client *Client) error {
+				client.Password = "wrong"
+				_, err := client.GetVersion()
+				return err
+			},
+			status:  http.StatusUnauthorized,
+			message: "could not get Cloud Connector version: [401 Unauthorized] check user and password of Cloud Connector",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientCopy := *client
+			err := tc.call(&clientCopy)
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			connectorError, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("error is %T, expected *Error", err)
+			}
+			if connectorError.StatusCode != tc.status {
+				t.Errorf("status code is %d, expected %d", connectorError.StatusCode, tc.status)
+			}
+			if IsNotFound(err) != tc.notFound {
+				t.Errorf("IsNotFound is %t, expected %t", IsNotFound(err), tc.notFound)
+			}
+			if err.Error() != tc.message {
+				t.Errorf("error message is %q, expected %q", err.Error(), tc.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// CreateResource create resource of Cloud Connector system mapping
+func (c *Client) CreateResource(regionHost string, subaccount string, virtualHost string, virtualPort string, resource models.SCCResource) error {
+	return c.do("POST", systemMappingPath(regionHost, subaccount, virtualHost, virtualPort)+"/resources", resource, nil,
+		"create resource "+resource.ID+" of system mapping "+virtualHost+":"+virtualPort)
+}
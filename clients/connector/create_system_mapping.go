@@ -0,0 +1,11 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// CreateSystemMapping create system mapping of Cloud Connector subaccount
+func (c *Client) CreateSystemMapping(regionHost string, subaccount string, systemMapping models.SCCSystemMapping) error {
+	return c.do("POST", subaccountPath(regionHost, subaccount)+"/systemMappings", systemMapping, nil,
+		"create system mapping "+systemMapping.VirtualHost+":"+systemMapping.VirtualPort)
+}
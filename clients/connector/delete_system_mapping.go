@@ -0,0 +1,8 @@
+package connector
+
+// DeleteSystemMapping delete system mapping of Cloud Connector subaccount
+// with its resources
+func (c *Client) DeleteSystemMapping(regionHost string, subaccount string, virtualHost string, virtualPort string) error {
+	return c.do("DELETE", systemMappingPath(regionHost, subaccount, virtualHost, virtualPort), nil, nil,
+		"delete system mapping "+virtualHost+":"+virtualPort)
+}
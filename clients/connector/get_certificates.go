@@ -0,0 +1,28 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// GetUICertificate get certificate of Cloud Connector administration UI
+func (c *Client) GetUICertificate() (models.SCCCertificate, error) {
+	var certificate models.SCCCertificate
+	err := c.do("GET", "/configuration/connector/ui/uiCertificate", nil, &certificate, "get UI certificate")
+	return certificate, err
+}
+
+// GetSystemCertificate get system certificate, which Cloud Connector uses
+// to authenticate against backend systems
+func (c *Client) GetSystemCertificate() (models.SCCCertificate, error) {
+	var certificate models.SCCCertificate
+	err := c.do("GET", "/configuration/connector/onPremise/systemCertificate", nil, &certificate, "get system certificate")
+	return certificate, err
+}
+
+// GetCACertificate get CA certificate, which Cloud Connector uses to issue
+// short-living certificates for principal propagation
+func (c *Client) GetCACertificate() (models.SCCCertificate, error) {
+	var certificate models.SCCCertificate
+	err := c.do("GET", "/configuration/connector/onPremise/ppCaCertificate", nil, &certificate, "get CA certificate")
+	return certificate, err
+}
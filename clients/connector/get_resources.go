@@ -0,0 +1,13 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// GetResources get resources of Cloud Connector system mapping
+func (c *Client) GetResources(regionHost string, subaccount string, virtualHost string, virtualPort string) ([]models.SCCResource, error) {
+	resources := make([]models.SCCResource, 0)
+	err := c.do("GET", systemMappingPath(regionHost, subaccount, virtualHost, virtualPort)+"/resources", nil, &resources,
+		"get resources of system mapping "+virtualHost+":"+virtualPort)
+	return resources, err
+}
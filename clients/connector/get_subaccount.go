@@ -0,0 +1,13 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// GetSubaccount get subaccount of Cloud Connector with tunnel state and
+// subaccount certificate
+func (c *Client) GetSubaccount(regionHost string, subaccount string) (models.SCCSubaccount, error) {
+	var result models.SCCSubaccount
+	err := c.do("GET", subaccountPath(regionHost, subaccount), nil, &result, "get subaccount "+subaccount)
+	return result, err
+}
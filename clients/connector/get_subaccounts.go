@@ -0,0 +1,12 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// GetSubaccounts get subaccounts connected to Cloud Connector
+func (c *Client) GetSubaccounts() ([]models.SCCSubaccount, error) {
+	subaccounts := make([]models.SCCSubaccount, 0)
+	err := c.do("GET", "/configuration/subaccounts", nil, &subaccounts, "get subaccounts")
+	return subaccounts, err
+}
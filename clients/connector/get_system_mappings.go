@@ -0,0 +1,12 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// GetSystemMappings get system mappings of Cloud Connector subaccount
+func (c *Client) GetSystemMappings(regionHost string, subaccount string) ([]models.SCCSystemMapping, error) {
+	systemMappings := make([]models.SCCSystemMapping, 0)
+	err := c.do("GET", subaccountPath(regionHost, subaccount)+"/systemMappings", nil, &systemMappings, "get system mappings")
+	return systemMappings, err
+}
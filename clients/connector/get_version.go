@@ -0,0 +1,12 @@
+package connector
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// GetVersion get version of Cloud Connector
+func (c *Client) GetVersion() (models.SCCVersion, error) {
+	var version models.SCCVersion
+	err := c.do("GET", "/connector/version", nil, &version, "get Cloud Connector version")
+	return version, err
+}
@@ -53,7 +53,11 @@ func CreateServiceInstance(cliConnection plugin.CliConnection, spaceGUID string,
 	} else if len(name) > 1 && name[len(name)-1:] == "-" {
 		name = name + servicePlan.Name + "-" + t
 	}
-	body = []byte("{" + serviceParameters + "\"type\":\"managed\",\"name\":\"" + name + "\",\"relationships\":{\"space\":{\"data\":{\"guid\":\"" + spaceGUID + "\"}},\"service_plan\":{\"data\":{\"guid\":\"" + servicePlan.GUID + "\"}}}}")
+	metadata, err := json.Marshal(PluginMetadata())
+	if err != nil {
+		return nil, err
+	}
+	body = []byte("{" + serviceParameters + "\"type\":\"managed\",\"name\":\"" + name + "\",\"metadata\":" + string(metadata) + ",\"relationships\":{\"space\":{\"data\":{\"guid\":\"" + spaceGUID + "\"}},\"service_plan\":{\"data\":{\"guid\":\"" + servicePlan.GUID + "\"}}}}")
 
 	log.Tracef("Making request to: %s %s\n", url, string(body))
 	request, err = http.NewRequest("POST", url, bytes.NewBuffer(body))
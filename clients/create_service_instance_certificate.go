@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"bytes"
+	models "cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// CreateServiceInstanceCertificate upload destination service instance certificate
+func CreateServiceInstanceCertificate(serviceURL string, accessToken string, certificate models.DestinationCertificate) error {
+	var err error
+	var request *http.Request
+	var response *http.Response
+	var certificatesURL string
+	var payload []byte
+	var body []byte
+
+	log.Tracef("Marshaling certificate %s of type %s\n", certificate.Name, certificate.Type)
+	payload, err = json.Marshal(certificate)
+	if err != nil {
+		return err
+	}
+
+	certificatesURL = serviceURL + "/destination-configuration/v1/instanceCertificates"
+	log.Tracef("Making request to: %s\n", certificatesURL)
+	request, err = http.NewRequest("POST", certificatesURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	client, err := GetDefaultClient()
+	if err != nil {
+		return err
+	}
+	response, err = client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err = io.ReadAll(response.Body)
+	log.Trace(log.Response{Head: response, Body: body})
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode > 201 {
+		return newDestinationError("upload certificate", response, body)
+	}
+
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	models "cf-cloud-connector/clients/models"
 	"cf-cloud-connector/log"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -51,7 +50,7 @@ func CreateServiceInstanceDestination(serviceURL string, accessToken string, des
 	}
 
 	if response.StatusCode > 201 {
-		return fmt.Errorf("could not create destination: [%s] %+v", response.Status, body)
+		return newDestinationError("create destination", response, body)
 	}
 
 	return nil
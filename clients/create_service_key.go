@@ -14,8 +14,13 @@ import (
 	"github.com/cloudfoundry/cli/plugin"
 )
 
-// CreateServiceKey create Cloud Foundry service key
+// CreateServiceKey create Cloud Foundry service key labeled as created by the plugin
 func CreateServiceKey(cliConnection plugin.CliConnection, serviceInstanceGUID string, parameters interface{}) (*models.CFServiceKey, error) {
+	return CreateServiceKeyWithMetadata(cliConnection, serviceInstanceGUID, parameters, PluginMetadata())
+}
+
+// CreateServiceKeyWithMetadata create Cloud Foundry service key with metadata
+func CreateServiceKeyWithMetadata(cliConnection plugin.CliConnection, serviceInstanceGUID string, parameters interface{}, metadata models.CFMetadata) (*models.CFServiceKey, error) {
 	var apiEndpoint string
 	var accessToken string
 	var request *http.Request
@@ -49,7 +54,11 @@ func CreateServiceKey(cliConnection plugin.CliConnection, serviceInstanceGUID st
 	} else {
 		serviceParameters = ""
 	}
-	body = []byte("{" + serviceParameters + "\"type\":\"key\",\"name\":\"" + "html5-key-" + t + "\",\"relationships\":{\"service_instance\":{\"data\":{\"guid\":\"" + serviceInstanceGUID + "\"}}}}")
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	body = []byte("{" + serviceParameters + "\"type\":\"key\",\"name\":\"" + ServiceKeyNamePrefix + t + "\",\"metadata\":" + string(metadataBytes) + ",\"relationships\":{\"service_instance\":{\"data\":{\"guid\":\"" + serviceInstanceGUID + "\"}}}}")
 
 	log.Tracef("Making request to: %s %s\n", url, string(body))
 	request, err = http.NewRequest("POST", url, bytes.NewBuffer(body))
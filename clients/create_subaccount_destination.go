@@ -4,7 +4,6 @@ import (
 	"bytes"
 	models "cf-cloud-connector/clients/models"
 	"cf-cloud-connector/log"
-	"fmt"
 	"io"
 	"net/http"
 )
@@ -51,10 +50,7 @@ func CreateSubaccountDestination(serviceURL string, accessToken string, destinat
 	}
 
 	if response.StatusCode > 201 {
-		body, err = io.ReadAll(response.Body)
-		if err != nil {
-			return fmt.Errorf("could not create destination: [%s] %+v", response.Status, body)
-		}
+		return newDestinationError("create destination", response, body)
 	}
 
 	return nil
@@ -60,6 +60,7 @@ func DeleteServiceInstance(cliConnection plugin.CliConnection, serviceInstanceGU
 
 		if response.StatusCode != 202 {
 			if currentTry != maxRetryCount {
+				currentTry++
 				continue
 			}
 			return fmt.Errorf("could not delete service instance: [%d] %s", response.StatusCode, string(body[:]))
@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"bytes"
+	"cf-cloud-connector/log"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// DeleteServiceInstanceCertificate delete destination service instance certificate
+func DeleteServiceInstanceCertificate(serviceURL string, accessToken string, certificateName string) error {
+	var err error
+	var request *http.Request
+	var response *http.Response
+	var certificateURL string
+	var payload = []byte{}
+	var body []byte
+
+	certificateURL = serviceURL + "/destination-configuration/v1/instanceCertificates/" + url.PathEscape(certificateName)
+	log.Tracef("Making request to: %s\n", certificateURL)
+	request, err = http.NewRequest("DELETE", certificateURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	client, err := GetDefaultClient()
+	if err != nil {
+		return err
+	}
+	response, err = client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	log.Trace(log.Response{Head: response})
+	if response.StatusCode > 204 {
+		body, err = io.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		return newDestinationError("delete certificate", response, body)
+	}
+
+	return nil
+}
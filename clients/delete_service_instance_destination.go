@@ -3,9 +3,9 @@ package clients
 import (
 	"bytes"
 	"cf-cloud-connector/log"
-	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 // DeleteServiceInstanceDestination delete destination service instance level destination
@@ -17,7 +17,7 @@ func DeleteServiceInstanceDestination(serviceURL string, accessToken string, des
 	var payload = []byte{}
 	var body []byte
 
-	destinationsURL = serviceURL + "/destination-configuration/v1/instanceDestinations/" + destinationName
+	destinationsURL = serviceURL + "/destination-configuration/v1/instanceDestinations/" + url.PathEscape(destinationName)
 	log.Tracef("Making request to: %s\n", destinationsURL)
 	request, err = http.NewRequest("DELETE", destinationsURL, bytes.NewBuffer(payload))
 	if err != nil {
@@ -36,11 +36,12 @@ func DeleteServiceInstanceDestination(serviceURL string, accessToken string, des
 	}
 	defer response.Body.Close()
 	log.Trace(log.Response{Head: response})
-	if response.StatusCode > 201 {
+	if response.StatusCode > 204 {
 		body, err = io.ReadAll(response.Body)
 		if err != nil {
-			return fmt.Errorf("could not delete destination: [%s] %+v", response.Status, body)
+			return err
 		}
+		return newDestinationError("delete destination", response, body)
 	}
 
 	return nil
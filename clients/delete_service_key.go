@@ -60,6 +60,7 @@ func DeleteServiceKey(cliConnection plugin.CliConnection, serviceKeyGUID string,
 
 		if response.StatusCode != 202 {
 			if currentTry != maxRetryCount {
+				currentTry++
 				continue
 			}
 			return fmt.Errorf("could not delete service key: [%d] %s", response.StatusCode, string(body[:]))
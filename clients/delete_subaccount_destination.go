@@ -3,9 +3,9 @@ package clients
 import (
 	"bytes"
 	"cf-cloud-connector/log"
-	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 // DeleteSubaccountDestination delete destination service subaccount destination
@@ -17,7 +17,7 @@ func DeleteSubaccountDestination(serviceURL string, accessToken string, destinat
 	var payload = []byte{}
 	var body []byte
 
-	destinationsURL = serviceURL + "/destination-configuration/v1/subaccountDestinations/" + destinationName
+	destinationsURL = serviceURL + "/destination-configuration/v1/subaccountDestinations/" + url.PathEscape(destinationName)
 	log.Tracef("Making request to: %s\n", destinationsURL)
 	request, err = http.NewRequest("DELETE", destinationsURL, bytes.NewBuffer(payload))
 	if err != nil {
@@ -36,11 +36,12 @@ func DeleteSubaccountDestination(serviceURL string, accessToken string, destinat
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode > 201 {
+	if response.StatusCode > 204 {
 		body, err = io.ReadAll(response.Body)
 		if err != nil {
-			return fmt.Errorf("could not delete destination: [%s] %+v", response.Status, body)
+			return err
 		}
+		return newDestinationError("delete destination", response, body)
 	} else {
 		log.Trace(log.Response{Head: response})
 	}
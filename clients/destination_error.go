@@ -0,0 +1,18 @@
+package clients
+
+import (
+	models "cf-cloud-connector/clients/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// newDestinationError creates error for failed destination service request,
+// using error message returned by destination service, if available
+func newDestinationError(action string, response *http.Response, body []byte) error {
+	var errorResponse models.DestinationErrorResponse
+	if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.ErrorMessage != "" {
+		return fmt.Errorf("could not %s: [%s] %s", action, response.Status, errorResponse.ErrorMessage)
+	}
+	return fmt.Errorf("could not %s: [%s] %s", action, response.Status, string(body))
+}
@@ -0,0 +1,63 @@
+package clients
+
+import (
+	models "cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// FindDestination find destination using destination service and resolve
+// its authentication tokens and certificates. User token is required
+// for destinations with user propagation, e.g. OAuth2SAMLBearerAssertion
+func FindDestination(serviceURL string, accessToken string, destinationName string, userToken string) (models.DestinationFindResponse, error) {
+	var destination models.DestinationFindResponse
+	var request *http.Request
+	var response *http.Response
+	var err error
+	var destinationURL string
+	var body []byte
+
+	destinationURL = serviceURL + "/destination-configuration/v1/destinations/" + url.PathEscape(destinationName)
+
+	log.Tracef("Making request to: %s\n", destinationURL)
+
+	client, err := GetDefaultClient()
+	if err != nil {
+		return destination, err
+	}
+	request, err = http.NewRequest("GET", destinationURL, nil)
+	if err != nil {
+		return destination, err
+	}
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+	if userToken != "" {
+		request.Header.Add("X-user-token", userToken)
+	}
+	response, err = client.Do(request)
+	if err != nil {
+		return destination, err
+	}
+
+	// Get response body
+	defer response.Body.Close()
+	body, err = io.ReadAll(response.Body)
+	log.Trace(log.Response{Head: response, Body: body})
+	if err != nil {
+		return destination, err
+	}
+
+	if response.StatusCode != 200 {
+		return destination, newDestinationError("find destination", response, body)
+	}
+
+	// Parse response JSON
+	err = json.Unmarshal(body, &destination)
+	if err != nil {
+		return destination, err
+	}
+
+	return destination, nil
+}
@@ -45,10 +45,11 @@ func GetServiceInstances(cliConnection plugin.CliConnection, spaceGUID string, s
 
 		for _, serviceInstance := range responseObject.Resources {
 			serviceInstances = append(serviceInstances, models.CFServiceInstance{
-				Name:          serviceInstance.Name,
-				GUID:          serviceInstance.GUID,
-				UpdatedAt:     serviceInstance.UpdatedAt,
-				LastOperation: serviceInstance.LastOperation,
+				Name:            serviceInstance.Name,
+				GUID:            serviceInstance.GUID,
+				ServicePlanGUID: serviceInstance.Relationships["service_plan"].Data.GUID,
+				UpdatedAt:       serviceInstance.UpdatedAt,
+				LastOperation:   serviceInstance.LastOperation,
 			})
 		}
 		if responseObject.Pagination.Next.Href != nil && *nextURL == *responseObject.Pagination.Next.Href {
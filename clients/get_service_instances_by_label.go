@@ -0,0 +1,70 @@
+package clients
+
+import (
+	models "cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// GetServiceInstancesByLabel get Cloud Foundry service instances of the
+// space matching label selector. All service instances of the space are
+// returned, if label selector is empty
+func GetServiceInstancesByLabel(cliConnection plugin.CliConnection, spaceGUID string, labelSelector string) ([]models.CFServiceInstance, error) {
+	var serviceInstances []models.CFServiceInstance
+	var responseObject models.CFResponse
+	var responseStrings []string
+	var err error
+	var nextURL *string
+	var pathStart int
+	var pathSlice string
+
+	serviceInstances = make([]models.CFServiceInstance, 0)
+	firstURL := "/v3/service_instances?space_guids=" + spaceGUID
+	if labelSelector != "" {
+		firstURL += "&label_selector=" + url.QueryEscape(labelSelector)
+	}
+	nextURL = &firstURL
+
+	for nextURL != nil {
+		log.Tracef("Making request to: %s\n", *nextURL)
+		responseStrings, err = cliConnection.CliCommandWithoutTerminalOutput("curl", *nextURL)
+		if err != nil {
+			return nil, err
+		}
+
+		responseObject = models.CFResponse{}
+		body := []byte(strings.Join(responseStrings, ""))
+		log.Trace(log.Response{Body: body})
+		err = json.Unmarshal(body, &responseObject)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, serviceInstance := range responseObject.Resources {
+			serviceInstances = append(serviceInstances, models.CFServiceInstance{
+				Name:          serviceInstance.Name,
+				GUID:          serviceInstance.GUID,
+				UpdatedAt:     serviceInstance.UpdatedAt,
+				LastOperation: serviceInstance.LastOperation,
+			})
+		}
+		if responseObject.Pagination.Next.Href != nil && *nextURL == *responseObject.Pagination.Next.Href {
+			log.Tracef("Unexpected value of the next page URL (equal to previous): %s\n", *nextURL)
+			break
+		}
+		nextURL = responseObject.Pagination.Next.Href
+		if nextURL != nil {
+			pathStart = strings.Index(*nextURL, "/v3/service_instances")
+			if pathStart > 0 {
+				pathSlice = (*nextURL)[pathStart:]
+				nextURL = &pathSlice
+			}
+		}
+	}
+
+	return serviceInstances, nil
+}
@@ -9,7 +9,8 @@ import (
 	"github.com/cloudfoundry/cli/plugin"
 )
 
-// GetServiceKeys get Cloud Foundry service keys
+// GetServiceKeys get Cloud Foundry service keys of service instance. App
+// bindings of service instance are not returned
 func GetServiceKeys(cliConnection plugin.CliConnection, serviceInstanceGUID string) ([]models.CFServiceKey, error) {
 	var serviceKeys []models.CFServiceKey
 	var responseObject models.CFResponse
@@ -21,7 +22,7 @@ func GetServiceKeys(cliConnection plugin.CliConnection, serviceInstanceGUID stri
 	var pathSlice string
 
 	serviceKeys = make([]models.CFServiceKey, 0)
-	firstURL := "/v3/service_credential_bindings?service_instance_guids=" + serviceInstanceGUID
+	firstURL := "/v3/service_credential_bindings?type=key&service_instance_guids=" + serviceInstanceGUID
 	nextURL = &firstURL
 
 	for nextURL != nil {
@@ -41,8 +42,10 @@ func GetServiceKeys(cliConnection plugin.CliConnection, serviceInstanceGUID stri
 
 		for _, serviceKey := range responseObject.Resources {
 			serviceKeys = append(serviceKeys, models.CFServiceKey{
-				Name: serviceKey.Name,
-				GUID: serviceKey.GUID,
+				Name:                serviceKey.Name,
+				GUID:                serviceKey.GUID,
+				ServiceInstanceGUID: serviceKey.Relationships["service_instance"].Data.GUID,
+				CreatedAt:           serviceKey.CreatedAt,
 			})
 		}
 		if responseObject.Pagination.Next.Href != nil && *nextURL == *responseObject.Pagination.Next.Href {
@@ -0,0 +1,73 @@
+package clients
+
+import (
+	models "cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// GetServiceKeysByLabel get Cloud Foundry service keys of service instances
+// matching label selector. All service keys of service instances are returned,
+// if label selector is empty. Credentials of service keys are not fetched
+func GetServiceKeysByLabel(cliConnection plugin.CliConnection, serviceInstanceGUIDs []string, labelSelector string) ([]models.CFServiceKey, error) {
+	var serviceKeys []models.CFServiceKey
+	var responseObject models.CFResponse
+	var responseStrings []string
+	var err error
+	var nextURL *string
+	var pathStart int
+	var pathSlice string
+
+	serviceKeys = make([]models.CFServiceKey, 0)
+	if len(serviceInstanceGUIDs) == 0 {
+		return serviceKeys, nil
+	}
+	firstURL := "/v3/service_credential_bindings?type=key&service_instance_guids=" + strings.Join(serviceInstanceGUIDs, ",")
+	if labelSelector != "" {
+		firstURL += "&label_selector=" + url.QueryEscape(labelSelector)
+	}
+	nextURL = &firstURL
+
+	for nextURL != nil {
+		log.Tracef("Making request to: %s\n", *nextURL)
+		responseStrings, err = cliConnection.CliCommandWithoutTerminalOutput("curl", *nextURL)
+		if err != nil {
+			return nil, err
+		}
+
+		responseObject = models.CFResponse{}
+		body := []byte(strings.Join(responseStrings, ""))
+		log.Trace(log.Response{Body: body})
+		err = json.Unmarshal(body, &responseObject)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, serviceKey := range responseObject.Resources {
+			serviceKeys = append(serviceKeys, models.CFServiceKey{
+				Name:                serviceKey.Name,
+				GUID:                serviceKey.GUID,
+				ServiceInstanceGUID: serviceKey.Relationships["service_instance"].Data.GUID,
+				CreatedAt:           serviceKey.CreatedAt,
+			})
+		}
+		if responseObject.Pagination.Next.Href != nil && *nextURL == *responseObject.Pagination.Next.Href {
+			log.Tracef("Unexpected value of the next page URL (equal to previous): %s\n", *nextURL)
+			break
+		}
+		nextURL = responseObject.Pagination.Next.Href
+		if nextURL != nil {
+			pathStart = strings.Index(*nextURL, "/v3/service_credential_bindings")
+			if pathStart > 0 {
+				pathSlice = (*nextURL)[pathStart:]
+				nextURL = &pathSlice
+			}
+		}
+	}
+
+	return serviceKeys, nil
+}
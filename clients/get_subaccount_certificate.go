@@ -0,0 +1,58 @@
+package clients
+
+import (
+	models "cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// GetSubaccountCertificate get destination service subaccount certificate with its content
+func GetSubaccountCertificate(serviceURL string, accessToken string, certificateName string) (models.DestinationCertificate, error) {
+	var certificate models.DestinationCertificate
+	var request *http.Request
+	var response *http.Response
+	var err error
+	var certificateURL string
+	var body []byte
+
+	certificateURL = serviceURL + "/destination-configuration/v1/subaccountCertificates/" + url.PathEscape(certificateName)
+
+	log.Tracef("Making request to: %s\n", certificateURL)
+
+	client, err := GetDefaultClient()
+	if err != nil {
+		return certificate, err
+	}
+	request, err = http.NewRequest("GET", certificateURL, nil)
+	if err != nil {
+		return certificate, err
+	}
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+	response, err = client.Do(request)
+	if err != nil {
+		return certificate, err
+	}
+
+	// Get response body
+	defer response.Body.Close()
+	body, err = io.ReadAll(response.Body)
+	log.Trace(log.Response{Head: response, Body: body})
+	if err != nil {
+		return certificate, err
+	}
+
+	if response.StatusCode != 200 {
+		return certificate, newDestinationError("get certificate", response, body)
+	}
+
+	// Parse response JSON
+	err = json.Unmarshal(body, &certificate)
+	if err != nil {
+		return certificate, err
+	}
+
+	return certificate, nil
+}
@@ -43,6 +43,10 @@ func ListServiceInstanceDestinations(serviceURL string, accessToken string) (mod
 		return destinations, err
 	}
 
+	if response.StatusCode != 200 {
+		return destinations, newDestinationError("list destinations", response, body)
+	}
+
 	// Parse response JSON
 	err = json.Unmarshal(body, &destinations)
 	if err != nil {
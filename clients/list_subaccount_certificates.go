@@ -0,0 +1,57 @@
+package clients
+
+import (
+	models "cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// ListSubaccountCertificates list destination service subaccount certificates
+func ListSubaccountCertificates(serviceURL string, accessToken string) ([]models.DestinationCertificate, error) {
+	var certificates []models.DestinationCertificate
+	var request *http.Request
+	var response *http.Response
+	var err error
+	var certificatesURL string
+	var body []byte
+
+	certificatesURL = serviceURL + "/destination-configuration/v1/subaccountCertificates"
+
+	log.Tracef("Making request to: %s\n", certificatesURL)
+
+	client, err := GetDefaultClient()
+	if err != nil {
+		return certificates, err
+	}
+	request, err = http.NewRequest("GET", certificatesURL, nil)
+	if err != nil {
+		return certificates, err
+	}
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+	response, err = client.Do(request)
+	if err != nil {
+		return certificates, err
+	}
+
+	// Get response body
+	defer response.Body.Close()
+	body, err = io.ReadAll(response.Body)
+	log.Trace(log.Response{Head: response, Body: body})
+	if err != nil {
+		return certificates, err
+	}
+
+	if response.StatusCode != 200 {
+		return certificates, newDestinationError("list certificates", response, body)
+	}
+
+	// Parse response JSON
+	err = json.Unmarshal(body, &certificates)
+	if err != nil {
+		return certificates, err
+	}
+
+	return certificates, nil
+}
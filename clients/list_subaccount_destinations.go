@@ -43,6 +43,10 @@ func ListSubaccountDestinations(serviceURL string, accessToken string) (models.D
 		return destinations, err
 	}
 
+	if response.StatusCode != 200 {
+		return destinations, newDestinationError("list destinations", response, body)
+	}
+
 	// Parse response JSON
 	err = json.Unmarshal(body, &destinations)
 	if err != nil {
@@ -75,6 +75,18 @@ type CFCredentials struct {
 	UAA                  *CFUAA                 `json:"uaa,omitempty"`
 	HTML5AppsRepo        *HTML5AppsRepo         `json:"html5-apps-repo,omitempty"`
 	Endpoints            *map[string]CFEndpoint `json:"endpoints,omitempty"`
+	Connectivity         *CFConnectivity        `json:"connectivity,omitempty"`
+}
+
+// CFConnectivity connectivity service credentials of on-premise proxy
+type CFConnectivity struct {
+	ProxyHost       string `json:"onpremise_proxy_host,omitempty"`
+	ProxyPort       string `json:"onpremise_proxy_port,omitempty"`
+	ProxyHTTPPort   string `json:"onpremise_proxy_http_port,omitempty"`
+	ProxyRFCPort    string `json:"onpremise_proxy_rfc_port,omitempty"`
+	ProxyLDAPPort   string `json:"onpremise_proxy_ldap_port,omitempty"`
+	SOCKS5ProxyPort string `json:"onpremise_socks5_proxy_port,omitempty"`
+	TokenServiceURL string `json:"token_service_url,omitempty"`
 }
 
 // UnmarshalJSON unmarshals Cloud Foundry service credentials
@@ -142,7 +154,11 @@ func (credentials *CFCredentials) UnmarshalJSON(data []byte) error {
 					credentials.UAA = &CFUAA{}
 				}
 				credentials.UAA.XSAPPNAME = v
+			default:
+				credentials.setConnectivity(key, v)
 			}
+		case float64:
+			credentials.setConnectivity(key, fmt.Sprintf("%g", v))
 		case map[string]interface{}:
 			switch key {
 			case "uaa":
@@ -235,3 +251,31 @@ type CFLastOperation struct {
 	UpdatedAt   string `json:"updated_at,omitempty"`
 	CreatedAt   string `json:"created_at,omitempty"`
 }
+
+// setConnectivity sets connectivity service credential, which is sent
+// either as string or as number. Other credentials are ignored
+func (credentials *CFCredentials) setConnectivity(key string, value string) {
+	connectivity := credentials.Connectivity
+	if connectivity == nil {
+		connectivity = &CFConnectivity{}
+	}
+	switch key {
+	case "onpremise_proxy_host":
+		connectivity.ProxyHost = value
+	case "onpremise_proxy_port":
+		connectivity.ProxyPort = value
+	case "onpremise_proxy_http_port":
+		connectivity.ProxyHTTPPort = value
+	case "onpremise_proxy_rfc_port":
+		connectivity.ProxyRFCPort = value
+	case "onpremise_proxy_ldap_port":
+		connectivity.ProxyLDAPPort = value
+	case "onpremise_socks5_proxy_port":
+		connectivity.SOCKS5ProxyPort = value
+	case "token_service_url":
+		connectivity.TokenServiceURL = value
+	default:
+		return
+	}
+	credentials.Connectivity = connectivity
+}
@@ -2,8 +2,9 @@ package models
 
 // CFServiceInstance Cloud Foundry service instance
 type CFServiceInstance struct {
-	Name          string
-	GUID          string
-	UpdatedAt     string
-	LastOperation CFLastOperation
+	Name            string
+	GUID            string
+	ServicePlanGUID string
+	UpdatedAt       string
+	LastOperation   CFLastOperation
 }
@@ -2,7 +2,9 @@ package models
 
 // CFServiceKey Cloud Foundry service
 type CFServiceKey struct {
-	Name        string
-	GUID        string
-	Credentials CFCredentials
+	Name                string
+	GUID                string
+	ServiceInstanceGUID string
+	CreatedAt           string
+	Credentials         CFCredentials
 }
@@ -0,0 +1,238 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Authentication types of destinations
+const (
+	NoAuthenticationType                = "NoAuthentication"
+	BasicAuthenticationType             = "BasicAuthentication"
+	PrincipalPropagationType            = "PrincipalPropagation"
+	OAuth2ClientCredentialsType         = "OAuth2ClientCredentials"
+	OAuth2SAMLBearerAssertionType       = "OAuth2SAMLBearerAssertion"
+	OAuth2UserTokenExchangeType         = "OAuth2UserTokenExchange"
+	OAuth2JWTBearerType                 = "OAuth2JWTBearer"
+	OAuth2PasswordType                  = "OAuth2Password"
+	SAMLAssertionType                   = "SAMLAssertion"
+	ClientCertificateAuthenticationType = "ClientCertificateAuthentication"
+)
+
+// DestinationAuthentication typed attributes of destination authentication
+// type. Fields of implementations are mapped to destination attributes by
+// `destination` struct tag
+type DestinationAuthentication interface {
+	AuthenticationType() string
+}
+
+// NoAuthentication attributes of destination without authentication
+type NoAuthentication struct{}
+
+// BasicAuthentication attributes of destination with basic authentication
+type BasicAuthentication struct {
+	User     string `destination:"User"`
+	Password string `destination:"Password"`
+}
+
+// PrincipalPropagation attributes of OnPremise destination, which propagates
+// user to Cloud Connector
+type PrincipalPropagation struct{}
+
+// OAuth2ClientCredentials attributes of destination with OAuth client
+// credentials flow
+type OAuth2ClientCredentials struct {
+	TokenServiceURL      string `destination:"tokenServiceURL"`
+	TokenServiceURLType  string `destination:"tokenServiceURLType"`
+	ClientID             string `destination:"clientId"`
+	ClientSecret         string `destination:"clientSecret"`
+	TokenServiceUser     string `destination:"tokenServiceUser"`
+	TokenServicePassword string `destination:"tokenServicePassword"`
+	KeyStoreLocation     string `destination:"tokenService.KeyStoreLocation"`
+	KeyStorePassword     string `destination:"tokenService.KeyStorePassword"`
+	Scope                string `destination:"scope"`
+}
+
+// OAuth2SAMLBearerAssertion attributes of destination with OAuth SAML bearer
+// assertion flow
+type OAuth2SAMLBearerAssertion struct {
+	Audience                         string `destination:"audience"`
+	ClientKey                        string `destination:"clientKey"`
+	TokenServiceURL                  string `destination:"tokenServiceURL"`
+	TokenServiceURLType              string `destination:"tokenServiceURLType"`
+	TokenServiceUser                 string `destination:"tokenServiceUser"`
+	TokenServicePassword             string `destination:"tokenServicePassword"`
+	AuthnContextClassRef             string `destination:"authnContextClassRef"`
+	NameIDFormat                     string `destination:"nameIdFormat"`
+	UserIDSource                     string `destination:"userIdSource"`
+	AssertionIssuer                  string `destination:"assertionIssuer"`
+	SkipSSOTokenGenerationWhenNoUser string `destination:"SkipSSOTokenGenerationWhenNoUser"`
+}
+
+// OAuth2UserTokenExchange attributes of destination with OAuth user token
+// exchange flow
+type OAuth2UserTokenExchange struct {
+	TokenServiceURL     string `destination:"tokenServiceURL"`
+	TokenServiceURLType string `destination:"tokenServiceURLType"`
+	ClientID            string `destination:"clientId"`
+	ClientSecret        string `destination:"clientSecret"`
+	KeyStoreLocation    string `destination:"tokenService.KeyStoreLocation"`
+	KeyStorePassword    string `destination:"tokenService.KeyStorePassword"`
+}
+
+// OAuth2JWTBearer attributes of destination with OAuth JWT bearer flow
+type OAuth2JWTBearer struct {
+	TokenServiceURL     string `destination:"tokenServiceURL"`
+	TokenServiceURLType string `destination:"tokenServiceURLType"`
+	ClientID            string `destination:"clientId"`
+	ClientSecret        string `destination:"clientSecret"`
+	KeyStoreLocation    string `destination:"tokenService.KeyStoreLocation"`
+	KeyStorePassword    string `destination:"tokenService.KeyStorePassword"`
+	Scope               string `destination:"scope"`
+}
+
+// OAuth2Password attributes of destination with OAuth resource owner
+// password flow
+type OAuth2Password struct {
+	TokenServiceURL string `destination:"tokenServiceURL"`
+	ClientID        string `destination:"clientId"`
+	ClientSecret    string `destination:"clientSecret"`
+	User            string `destination:"User"`
+	Password        string `destination:"Password"`
+	Scope           string `destination:"scope"`
+}
+
+// SAMLAssertion attributes of destination with SAML assertion authentication
+type SAMLAssertion struct {
+	Audience             string `destination:"audience"`
+	AuthnContextClassRef string `destination:"authnContextClassRef"`
+	NameIDFormat         string `destination:"nameIdFormat"`
+	UserIDSource         string `destination:"userIdSource"`
+	AssertionIssuer      string `destination:"assertionIssuer"`
+}
+
+// ClientCertificateAuthentication attributes of destination with client
+// certificate authentication
+type ClientCertificateAuthentication struct {
+	KeyStoreLocation string `destination:"KeyStoreLocation"`
+	KeyStorePassword string `destination:"KeyStorePassword"`
+}
+
+// AuthenticationType returns authentication type
+func (*NoAuthentication) AuthenticationType() string { return NoAuthenticationType }
+
+// AuthenticationType returns authentication type
+func (*BasicAuthentication) AuthenticationType() string { return BasicAuthenticationType }
+
+// AuthenticationType returns authentication type
+func (*PrincipalPropagation) AuthenticationType() string { return PrincipalPropagationType }
+
+// AuthenticationType returns authentication type
+func (*OAuth2ClientCredentials) AuthenticationType() string { return OAuth2ClientCredentialsType }
+
+// AuthenticationType returns authentication type
+func (*OAuth2SAMLBearerAssertion) AuthenticationType() string { return OAuth2SAMLBearerAssertionType }
+
+// AuthenticationType returns authentication type
+func (*OAuth2UserTokenExchange) AuthenticationType() string { return OAuth2UserTokenExchangeType }
+
+// AuthenticationType returns authentication type
+func (*OAuth2JWTBearer) AuthenticationType() string { return OAuth2JWTBearerType }
+
+// AuthenticationType returns authentication type
+func (*OAuth2Password) AuthenticationType() string { return OAuth2PasswordType }
+
+// AuthenticationType returns authentication type
+func (*SAMLAssertion) AuthenticationType() string { return SAMLAssertionType }
+
+// AuthenticationType returns authentication type
+func (*ClientCertificateAuthentication) AuthenticationType() string {
+	return ClientCertificateAuthenticationType
+}
+
+// NewDestinationAuthentication creates empty typed attributes of authentication
+// type. Nil is returned for unknown authentication types
+func NewDestinationAuthentication(authenticationType string) DestinationAuthentication {
+	switch authenticationType {
+	case NoAuthenticationType:
+		return &NoAuthentication{}
+	case BasicAuthenticationType:
+		return &BasicAuthentication{}
+	case PrincipalPropagationType:
+		return &PrincipalPropagation{}
+	case OAuth2ClientCredentialsType:
+		return &OAuth2ClientCredentials{}
+	case OAuth2SAMLBearerAssertionType:
+		return &OAuth2SAMLBearerAssertion{}
+	case OAuth2UserTokenExchangeType:
+		return &OAuth2UserTokenExchange{}
+	case OAuth2JWTBearerType:
+		return &OAuth2JWTBearer{}
+	case OAuth2PasswordType:
+		return &OAuth2Password{}
+	case SAMLAssertionType:
+		return &SAMLAssertion{}
+	case ClientCertificateAuthenticationType:
+		return &ClientCertificateAuthentication{}
+	}
+	return nil
+}
+
+// AuthenticationAttributeNames returns names of destination attributes of
+// authentication type, sorted by name
+func AuthenticationAttributeNames(authenticationType string) []string {
+	names := make([]string, 0)
+	auth := NewDestinationAuthentication(authenticationType)
+	if auth == nil {
+		return names
+	}
+	authType := reflect.TypeOf(auth).Elem()
+	for idx := 0; idx < authType.NumField(); idx++ {
+		names = append(names, authType.Field(idx).Tag.Get("destination"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// authenticationAttributes returns non-empty attributes of authentication
+func authenticationAttributes(auth DestinationAuthentication) map[string]string {
+	attributes := make(map[string]string)
+	if auth == nil {
+		return attributes
+	}
+	authValue := reflect.ValueOf(auth).Elem()
+	for idx := 0; idx < authValue.NumField(); idx++ {
+		if value := authValue.Field(idx).String(); value != "" {
+			attributes[authValue.Type().Field(idx).Tag.Get("destination")] = value
+		}
+	}
+	return attributes
+}
+
+// authenticationField returns field of authentication mapped to attribute
+func authenticationField(auth DestinationAuthentication, key string) (reflect.Value, bool) {
+	if auth == nil {
+		return reflect.Value{}, false
+	}
+	authValue := reflect.ValueOf(auth).Elem()
+	for idx := 0; idx < authValue.NumField(); idx++ {
+		if authValue.Type().Field(idx).Tag.Get("destination") == key {
+			return authValue.Field(idx), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// copyAuthentication returns deep copy of authentication, which values
+// are passed through transform function
+func copyAuthentication(auth DestinationAuthentication, transform func(key string, value string) string) DestinationAuthentication {
+	if auth == nil {
+		return nil
+	}
+	authCopy := NewDestinationAuthentication(auth.AuthenticationType())
+	for key, value := range authenticationAttributes(auth) {
+		field, _ := authenticationField(authCopy, key)
+		field.SetString(transform(key, value))
+	}
+	return authCopy
+}
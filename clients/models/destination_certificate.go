@@ -0,0 +1,10 @@
+package models
+
+// DestinationCertificate certificate, e.g. key store or trust store, stored
+// in destination service on subaccount or service instance level. Content
+// is base64-encoded
+type DestinationCertificate struct {
+	Name    string `json:"Name"`
+	Type    string `json:"Type,omitempty"`
+	Content string `json:"Content,omitempty"`
+}
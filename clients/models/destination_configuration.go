@@ -1,51 +1,340 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// RedactedValue placeholder for values of secret destination attributes
+const RedactedValue = "***REDACTED***"
 
 // DestinationListDestinationsResponse destination service list of destination configurations
 type DestinationListDestinationsResponse = []DestinationConfiguration
 
-// DestinationConfiguration destination configuration object
+// DestinationConfiguration destination configuration object. Attributes of
+// authentication type are kept in typed Auth, other unknown attributes are
+// kept in Properties. Attributes with non-string values, e.g. booleans,
+// numbers or objects, are kept as raw JSON in RawProperties. Standard and
+// authentication attributes, which are present with empty values, are
+// remembered, so that they are marshaled back
 type DestinationConfiguration struct {
-	Name                           string `json:"Name,omitempty"`
-	Description                    string `json:"Description,omitempty"`
-	Type                           string `json:"Type,omitempty"`
-	URL                            string `json:"URL,omitempty"`
-	Authentication                 string `json:"Authentication,omitempty"`
-	ProxyType                      string `json:"ProxyType,omitempty"`
-	TokenServiceURL                string `json:"tokenServiceURL,omitempty"`
-	TokenServiceURLType            string `json:"tokenServiceURLType,omitempty"`
-	ClientID                       string `json:"clientId,omitempty"`
-	ClientSecret                   string `json:"clientSecret,omitempty"`
+	Name                           string                    `json:"Name,omitempty"`
+	Description                    string                    `json:"Description,omitempty"`
+	Type                           string                    `json:"Type,omitempty"`
+	URL                            string                    `json:"URL,omitempty"`
+	Authentication                 string                    `json:"Authentication,omitempty"`
+	ProxyType                      string                    `json:"ProxyType,omitempty"`
+	Auth                           DestinationAuthentication `json:"-"`
 	Properties                     map[string]string
+	RawProperties                  map[string]json.RawMessage
 	DestinationServiceInstanceName string
+	emptyAttributes                map[string]bool
+}
+
+// MarshalJSON marshals destination configuration. Attributes with empty
+// values are omitted, unless they were present with empty values, and raw
+// properties are marshaled as is. Value receiver makes non-addressable
+// values, e.g. in interfaces, marshal the same way
+func (dc DestinationConfiguration) MarshalJSON() ([]byte, error) {
+	jsonMap := make(map[string]interface{})
+	for key, value := range dc.stringMap() {
+		jsonMap[key] = value
+	}
+	for key, value := range dc.RawProperties {
+		jsonMap[key] = value
+	}
+	return json.Marshal(jsonMap)
+}
+
+// Map returns destination attributes and properties as flat map, the way
+// they are represented in destination service. Attributes with empty
+// values are omitted, unless they were present with empty values, and raw
+// properties are represented by their JSON
+func (dc *DestinationConfiguration) Map() map[string]string {
+	jsonMap := dc.stringMap()
+	for key, value := range dc.RawProperties {
+		jsonMap[key] = string(value)
+	}
+	return jsonMap
 }
 
-// MarshalJSON marshals destination configuration
-func (dc *DestinationConfiguration) MarshalJSON() ([]byte, error) {
+// stringMap returns attributes and properties with string values
+func (dc *DestinationConfiguration) stringMap() map[string]string {
 	jsonMap := make(map[string]string)
-	jsonMap["Name"] = dc.Name
-	jsonMap["Description"] = dc.Description
-	jsonMap["Type"] = dc.Type
-	jsonMap["URL"] = dc.URL
-	jsonMap["Authentication"] = dc.Authentication
-	jsonMap["ProxyType"] = dc.ProxyType
-	jsonMap["tokenServiceURL"] = dc.TokenServiceURL
-	jsonMap["tokenServiceURLType"] = dc.TokenServiceURLType
-	jsonMap["clientId"] = dc.ClientID
-	jsonMap["clientSecret"] = dc.ClientSecret
+	for key, value := range map[string]string{
+		"Name":           dc.Name,
+		"Description":    dc.Description,
+		"Type":           dc.Type,
+		"URL":            dc.URL,
+		"Authentication": dc.Authentication,
+		"ProxyType":      dc.ProxyType,
+	} {
+		if value != "" {
+			jsonMap[key] = value
+		}
+	}
+	for key, value := range authenticationAttributes(dc.Auth) {
+		jsonMap[key] = value
+	}
+	for key := range dc.emptyAttributes {
+		if _, ok := jsonMap[key]; !ok {
+			jsonMap[key] = ""
+		}
+	}
 	for key, value := range dc.Properties {
 		jsonMap[key] = value
 	}
-	return json.Marshal(jsonMap)
+	return jsonMap
+}
+
+// GetProperty returns value of destination attribute or property by its name
+func (dc *DestinationConfiguration) GetProperty(key string) (string, bool) {
+	switch key {
+	case "Name":
+		return dc.Name, dc.Name != ""
+	case "Description":
+		return dc.Description, dc.Description != ""
+	case "Type":
+		return dc.Type, dc.Type != ""
+	case "URL":
+		return dc.URL, dc.URL != ""
+	case "Authentication":
+		return dc.Authentication, dc.Authentication != ""
+	case "ProxyType":
+		return dc.ProxyType, dc.ProxyType != ""
+	}
+	if field, ok := authenticationField(dc.Auth, key); ok && field.String() != "" {
+		return field.String(), true
+	}
+	if value, ok := dc.RawProperties[key]; ok {
+		return string(value), true
+	}
+	value, ok := dc.Properties[key]
+	return value, ok
+}
+
+// SetProperty sets value of destination attribute or property by its name.
+// Changing authentication type moves attributes between typed Auth and
+// Properties, so that no attribute is lost
+func (dc *DestinationConfiguration) SetProperty(key string, value string) {
+	delete(dc.RawProperties, key)
+	delete(dc.emptyAttributes, key)
+	switch key {
+	case "Name":
+		dc.Name = value
+	case "Description":
+		dc.Description = value
+	case "Type":
+		dc.Type = value
+	case "URL":
+		dc.URL = value
+	case "Authentication":
+		dc.Authentication = value
+		dc.syncAuthentication()
+	case "ProxyType":
+		dc.ProxyType = value
+	default:
+		dc.syncAuthentication()
+		if field, ok := authenticationField(dc.Auth, key); ok {
+			field.SetString(value)
+			delete(dc.Properties, key)
+			dc.markEmptyAttribute(key, value)
+			return
+		}
+		if dc.Properties == nil {
+			dc.Properties = make(map[string]string)
+		}
+		dc.Properties[key] = value
+		return
+	}
+	dc.markEmptyAttribute(key, value)
+}
+
+// markEmptyAttribute remembers standard or authentication attribute, which is
+// set to empty value, so that it is not omitted
+func (dc *DestinationConfiguration) markEmptyAttribute(key string, value string) {
+	if value != "" {
+		return
+	}
+	if dc.emptyAttributes == nil {
+		dc.emptyAttributes = make(map[string]bool)
+	}
+	dc.emptyAttributes[key] = true
+}
+
+// UnsetProperty removes destination attribute or property by its name
+func (dc *DestinationConfiguration) UnsetProperty(key string) {
+	if _, ok := dc.RawProperties[key]; ok {
+		delete(dc.RawProperties, key)
+		return
+	}
+	if _, ok := dc.Properties[key]; ok {
+		delete(dc.Properties, key)
+		return
+	}
+	if _, ok := dc.GetProperty(key); ok {
+		dc.SetProperty(key, "")
+	}
+	delete(dc.emptyAttributes, key)
+}
+
+// syncAuthentication makes typed Auth match authentication type. Attributes
+// of previous Auth and matching Properties are moved to new Auth, the rest
+// of attributes is moved to Properties
+func (dc *DestinationConfiguration) syncAuthentication() {
+	if dc.Auth != nil && dc.Auth.AuthenticationType() == dc.Authentication {
+		return
+	}
+	attributes := authenticationAttributes(dc.Auth)
+	dc.Auth = NewDestinationAuthentication(dc.Authentication)
+	for key, value := range dc.Properties {
+		if _, ok := authenticationField(dc.Auth, key); ok {
+			attributes[key] = value
+			delete(dc.Properties, key)
+		}
+	}
+	for key, value := range attributes {
+		if field, ok := authenticationField(dc.Auth, key); ok {
+			field.SetString(value)
+			continue
+		}
+		if dc.Properties == nil {
+			dc.Properties = make(map[string]string)
+		}
+		dc.Properties[key] = value
+	}
+}
+
+// Redacted returns copy of destination configuration with values
+// of secret attributes replaced by placeholder
+func (dc DestinationConfiguration) Redacted() DestinationConfiguration {
+	redact := func(key string, value string) string {
+		if IsSecretProperty(key) && value != "" {
+			return RedactedValue
+		}
+		return value
+	}
+	dc.Auth = copyAuthentication(dc.Auth, redact)
+	properties := dc.Properties
+	dc.Properties = nil
+	for key, value := range properties {
+		if dc.Properties == nil {
+			dc.Properties = make(map[string]string)
+		}
+		dc.Properties[key] = redact(key, value)
+	}
+	rawProperties := dc.RawProperties
+	dc.RawProperties = nil
+	for key, value := range rawProperties {
+		if dc.RawProperties == nil {
+			dc.RawProperties = make(map[string]json.RawMessage)
+		}
+		if IsSecretProperty(key) {
+			value, _ = json.Marshal(RedactedValue)
+		}
+		dc.RawProperties[key] = value
+	}
+	emptyAttributes := dc.emptyAttributes
+	dc.emptyAttributes = nil
+	for key := range emptyAttributes {
+		dc.markEmptyAttribute(key, "")
+	}
+	return dc
 }
 
-// UnmarshalJSON unmarshals destination configuration
+// Copy returns deep copy of destination configuration
+func (dc DestinationConfiguration) Copy() DestinationConfiguration {
+	dc.Auth = copyAuthentication(dc.Auth, func(key string, value string) string {
+		return value
+	})
+	properties := dc.Properties
+	dc.Properties = nil
+	for key, value := range properties {
+		if dc.Properties == nil {
+			dc.Properties = make(map[string]string)
+		}
+		dc.Properties[key] = value
+	}
+	rawProperties := dc.RawProperties
+	dc.RawProperties = nil
+	for key, value := range rawProperties {
+		if dc.RawProperties == nil {
+			dc.RawProperties = make(map[string]json.RawMessage)
+		}
+		dc.RawProperties[key] = append(json.RawMessage{}, value...)
+	}
+	emptyAttributes := dc.emptyAttributes
+	dc.emptyAttributes = nil
+	for key := range emptyAttributes {
+		dc.markEmptyAttribute(key, "")
+	}
+	return dc
+}
+
+// CloudConnectorLocationIDProperty property of OnPremise destinations, which
+// selects Cloud Connector of subaccount. Empty value means default location
+const CloudConnectorLocationIDProperty = "CloudConnectorLocationId"
+
+// CloudConnectorLocationID returns location ID of Cloud Connector serving
+// OnPremise destination. False is returned for other proxy types
+func (dc DestinationConfiguration) CloudConnectorLocationID() (string, bool) {
+	if !strings.EqualFold(dc.ProxyType, "OnPremise") {
+		return "", false
+	}
+	locationID, _ := dc.GetProperty(CloudConnectorLocationIDProperty)
+	return locationID, true
+}
+
+// VirtualHost returns virtual host and port, which destination connects to.
+// RFC destinations return application server host with gateway service or
+// message server host with message server service. Empty string is returned,
+// if destination does not define host
+func (dc DestinationConfiguration) VirtualHost() string {
+	if strings.EqualFold(dc.Type, "RFC") {
+		if host, ok := dc.GetProperty("jco.client.ashost"); ok && host != "" {
+			if sysnr, ok := dc.GetProperty("jco.client.sysnr"); ok && sysnr != "" {
+				return host + ":sapgw" + sysnr
+			}
+			return host
+		}
+		if host, ok := dc.GetProperty("jco.client.mshost"); ok && host != "" {
+			if systemID, ok := dc.GetProperty("jco.client.r3name"); ok && systemID != "" {
+				return host + ":sapms" + systemID
+			}
+			return host
+		}
+		return ""
+	}
+	parsedURL, err := url.Parse(strings.TrimSpace(dc.URL))
+	if err != nil {
+		return ""
+	}
+	return parsedURL.Host
+}
+
+// UnmarshalJSON unmarshals destination configuration. Attributes of known
+// authentication types are unmarshaled to typed Auth. Attributes with
+// non-string values are kept as raw JSON, so that destination can be
+// marshaled back without losing them
 func (dc *DestinationConfiguration) UnmarshalJSON(data []byte) error {
-	jsonMap := make(map[string]string)
-	if err := json.Unmarshal(data, &jsonMap); err != nil {
+	rawMap := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &rawMap); err != nil {
 		return err
 	}
+	jsonMap := make(map[string]string)
+	for key, rawValue := range rawMap {
+		var value string
+		if err := json.Unmarshal(rawValue, &value); err != nil || string(rawValue) == "null" {
+			if dc.RawProperties == nil {
+				dc.RawProperties = make(map[string]json.RawMessage)
+			}
+			dc.RawProperties[key] = rawValue
+			continue
+		}
+		jsonMap[key] = value
+	}
+	dc.Auth = NewDestinationAuthentication(jsonMap["Authentication"])
 	for key, value := range jsonMap {
 		switch key {
 		case "Name":
@@ -60,20 +349,38 @@ func (dc *DestinationConfiguration) UnmarshalJSON(data []byte) error {
 			dc.Authentication = value
 		case "ProxyType":
 			dc.ProxyType = value
-		case "tokenServiceURL":
-			dc.TokenServiceURL = value
-		case "TokenServiceURLType":
-			dc.TokenServiceURLType = value
-		case "clientId":
-			dc.ClientID = value
-		case "clientSecret":
-			dc.ClientSecret = value
 		default:
+			if field, ok := authenticationField(dc.Auth, key); ok {
+				field.SetString(value)
+				dc.markEmptyAttribute(key, value)
+				continue
+			}
 			if dc.Properties == nil {
 				dc.Properties = make(map[string]string)
 			}
 			dc.Properties[key] = value
+			continue
 		}
+		dc.markEmptyAttribute(key, value)
 	}
 	return nil
 }
+
+// secretPropertyNames destination properties holding secret values, which
+// names do not end with password, secret or token, e.g. passwords of RFC
+// destinations. Names are in lower case
+var secretPropertyNames = map[string]bool{
+	"jco.client.passwd":                 true,
+	"jco.client.mysapsso2":              true,
+	"jco.destination.repository.passwd": true,
+}
+
+// IsSecretProperty checks if destination property holds secret value,
+// e.g. password, client secret or token
+func IsSecretProperty(key string) bool {
+	lowerKey := strings.ToLower(key)
+	return secretPropertyNames[lowerKey] ||
+		strings.HasSuffix(lowerKey, "password") ||
+		strings.HasSuffix(lowerKey, "secret") ||
+		strings.HasSuffix(lowerKey, "token")
+}
@@ -0,0 +1,308 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var authenticationTypes = []string{
+	NoAuthenticationType,
+	BasicAuthenticationType,
+	PrincipalPropagationType,
+	OAuth2ClientCredentialsType,
+	OAuth2SAMLBearerAssertionType,
+	OAuth2UserTokenExchangeType,
+	OAuth2JWTBearerType,
+	OAuth2PasswordType,
+	SAMLAssertionType,
+	ClientCertificateAuthenticationType,
+}
+
+// destinationJSON returns JSON object of destination with authentication type,
+// standard attributes and properties of all kinds of values
+func destinationJSON(t *testing.T, authenticationType string, authValue func(idx int) string) string {
+	attributes := map[string]interface{}{
+		"Name":                     "dest",
+		"Description":              "",
+		"Type":                     "HTTP",
+		"URL":                      "https://example.com",
+		"Authentication":           authenticationType,
+		"ProxyType":                "Internet",
+		"custom":                   "value",
+		"emptyCustom":              "",
+		"HTML5.DynamicDestination": true,
+		"timeout":                  30,
+		"nested":                   map[string]interface{}{"key": "value"},
+		"nullValue":                nil,
+	}
+	for idx, name := range AuthenticationAttributeNames(authenticationType) {
+		attributes[name] = authValue(idx)
+	}
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		t.Fatalf("could not marshal destination: %s", err)
+	}
+	return string(data)
+}
+
+// assertSameJSON fails test, if JSON documents are not equal
+func assertSameJSON(t *testing.T, expected string, actual string) {
+	t.Helper()
+	var expectedValue, actualValue interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("could not unmarshal expected JSON: %s", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &actualValue); err != nil {
+		t.Fatalf("could not unmarshal actual JSON: %s", err)
+	}
+	if !reflect.DeepEqual(expectedValue, actualValue) {
+		t.Errorf("JSON differs:\nexpected: %s\nactual:   %s", expected, actual)
+	}
+}
+
+func TestDestinationConfigurationRoundTrip(t *testing.T) {
+	type testCase struct {
+		name  string
+		input string
+	}
+	testCases := []testCase{
+		{name: "minimal", input: `{"Name":"dest"}`},
+		{name: "empty standard attributes", input: `{"Name":"dest","Description":"","Type":"","URL":"","Authentication":"","ProxyType":""}`},
+		{name: "raw values", input: `{"Name":"dest","flag":false,"count":1.5,"object":{"list":[1,"a"]},"null":null}`},
+		{name: "unknown authentication type", input: `{"Name":"dest","Authentication":"Custom","User":"","Password":"secret"}`},
+	}
+	for _, authenticationType := range authenticationTypes {
+		testCases = append(testCases,
+			testCase{
+				name:  authenticationType + " with values",
+				input: destinationJSON(t, authenticationType, func(int) string { return "value" }),
+			},
+			testCase{
+				name:  authenticationType + " with empty values",
+				input: destinationJSON(t, authenticationType, func(int) string { return "" }),
+			},
+			testCase{
+				name: authenticationType + " with mixed values",
+				input: destinationJSON(t, authenticationType, func(idx int) string {
+					if idx%2 == 0 {
+						return ""
+					}
+					return "value"
+				}),
+			})
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var destination DestinationConfiguration
+			if err := json.Unmarshal([]byte(tc.input), &destination); err != nil {
+				t.Fatalf("could not unmarshal destination: %s", err)
+			}
+			if destination.Auth != nil {
+				for key := range authenticationAttributes(destination.Auth) {
+					if _, ok := destination.Properties[key]; ok {
+						t.Errorf("attribute %s of authentication is kept in properties", key)
+					}
+				}
+			}
+
+			data, err := json.Marshal(destination)
+			if err != nil {
+				t.Fatalf("could not marshal destination: %s", err)
+			}
+			assertSameJSON(t, tc.input, string(data))
+
+			// Copies and values in interfaces marshal the same way
+			data, err = json.Marshal(destination.Copy())
+			if err != nil {
+				t.Fatalf("could not marshal copy of destination: %s", err)
+			}
+			assertSameJSON(t, tc.input, string(data))
+			data, err = json.Marshal([]interface{}{destination})
+			if err != nil {
+				t.Fatalf("could not marshal destination in interface: %s", err)
+			}
+			assertSameJSON(t, "["+tc.input+"]", string(data))
+		})
+	}
+}
+
+func TestDestinationConfigurationUnsetEmptyAttribute(t *testing.T) {
+	var destination DestinationConfiguration
+	input := `{"Name":"dest","Description":"","Authentication":"BasicAuthentication","User":"","Password":"secret"}`
+	if err := json.Unmarshal([]byte(input), &destination); err != nil {
+		t.Fatalf("could not unmarshal destination: %s", err)
+	}
+
+	destination.SetProperty("User", "user")
+	destination.UnsetProperty("Description")
+	destination.UnsetProperty("Password")
+	data, err := json.Marshal(destination)
+	if err != nil {
+		t.Fatalf("could not marshal destination: %s", err)
+	}
+	assertSameJSON(t, `{"Name":"dest","Authentication":"BasicAuthentication","User":"user"}`, string(data))
+
+	destination.SetProperty("Description", "")
+	data, err = json.Marshal(destination)
+	if err != nil {
+		t.Fatalf("could not marshal destination: %s", err)
+	}
+	assertSameJSON(t, `{"Name":"dest","Description":"","Authentication":"BasicAuthentication","User":"user"}`, string(data))
+}
+
+func TestDestinationConfigurationSetAuthentication(t *testing.T) {
+	testCases := []struct {
+		name               string
+		input              string
+		authenticationType string
+		auth               DestinationAuthentication
+		properties         map[string]string
+	}{
+		{
+			name:               "attributes shared by authentication types stay in Auth",
+			input:              `{"Name":"dest","Authentication":"BasicAuthentication","User":"user","Password":"secret"}`,
+			authenticationType: OAuth2PasswordType,
+			auth:               &OAuth2Password{User: "user", Password: "secret"},
+		},
+		{
+			name:               "attributes of previous authentication type move to Properties",
+			input:              `{"Name":"dest","Authentication":"BasicAuthentication","User":"user","Password":"secret","scope":"read"}`,
+			authenticationType: OAuth2ClientCredentialsType,
+			auth:               &OAuth2ClientCredentials{Scope: "read"},
+			properties:         map[string]string{"User": "user", "Password": "secret"},
+		},
+		{
+			name:               "properties of new authentication type move to Auth",
+			input:              `{"Name":"dest","Authentication":"NoAuthentication","clientId":"client","clientSecret":"secret","custom":"value"}`,
+			authenticationType: OAuth2JWTBearerType,
+			auth:               &OAuth2JWTBearer{ClientID: "client", ClientSecret: "secret"},
+			properties:         map[string]string{"custom": "value"},
+		},
+		{
+			name:               "unknown authentication type keeps attributes in Properties",
+			input:              `{"Name":"dest","Authentication":"ClientCertificateAuthentication","KeyStoreLocation":"cert.p12","KeyStorePassword":"secret"}`,
+			authenticationType: "Custom",
+			properties:         map[string]string{"KeyStoreLocation": "cert.p12", "KeyStorePassword": "secret"},
+		},
+		{
+			name:               "known authentication type takes attributes from Properties",
+			input:              `{"Name":"dest","Authentication":"Custom","KeyStoreLocation":"cert.p12","KeyStorePassword":"secret"}`,
+			authenticationType: ClientCertificateAuthenticationType,
+			auth:               &ClientCertificateAuthentication{KeyStoreLocation: "cert.p12", KeyStorePassword: "secret"},
+		},
+		{
+			name:               "empty attributes are kept",
+			input:              `{"Name":"dest","Authentication":"BasicAuthentication","User":"","Password":"secret"}`,
+			authenticationType: OAuth2ClientCredentialsType,
+			auth:               &OAuth2ClientCredentials{},
+			properties:         map[string]string{"Password": "secret"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var destination DestinationConfiguration
+			if err := json.Unmarshal([]byte(tc.input), &destination); err != nil {
+				t.Fatalf("could not unmarshal destination: %s", err)
+			}
+			attributes := destination.Map()
+
+			destination.SetProperty("Authentication", tc.authenticationType)
+			if !reflect.DeepEqual(destination.Auth, tc.auth) {
+				t.Errorf("Auth is %#v, expected %#v", destination.Auth, tc.auth)
+			}
+			properties := destination.Properties
+			if len(properties) == 0 {
+				properties = nil
+			}
+			if !reflect.DeepEqual(properties, tc.properties) {
+				t.Errorf("Properties are %v, expected %v", destination.Properties, tc.properties)
+			}
+
+			// No attribute is lost, only authentication type changes
+			attributes["Authentication"] = tc.authenticationType
+			if actual := destination.Map(); !reflect.DeepEqual(actual, attributes) {
+				t.Errorf("attributes are %v, expected %v", actual, attributes)
+			}
+
+			// Changing authentication type back restores attributes
+			var original DestinationConfiguration
+			if err := json.Unmarshal([]byte(tc.input), &original); err != nil {
+				t.Fatalf("could not unmarshal destination: %s", err)
+			}
+			destination.SetProperty("Authentication", original.Authentication)
+			if !reflect.DeepEqual(destination.Auth, original.Auth) {
+				t.Errorf("Auth is %#v after changing authentication type back, expected %#v", destination.Auth, original.Auth)
+			}
+			if actual, expected := destination.Map(), original.Map(); !reflect.DeepEqual(actual, expected) {
+				t.Errorf("attributes are %v after changing authentication type back, expected %v", actual, expected)
+			}
+		})
+	}
+}
+
+func TestDestinationConfigurationSetAuthenticationAttribute(t *testing.T) {
+	destination := DestinationConfiguration{Name: "dest"}
+	destination.SetProperty("User", "user")
+	destination.SetProperty("Authentication", BasicAuthenticationType)
+	destination.SetProperty("Password", "secret")
+
+	expectedAuth := &BasicAuthentication{User: "user", Password: "secret"}
+	if !reflect.DeepEqual(destination.Auth, expectedAuth) {
+		t.Errorf("Auth is %#v, expected %#v", destination.Auth, expectedAuth)
+	}
+	if len(destination.Properties) > 0 {
+		t.Errorf("Properties are %v, expected none", destination.Properties)
+	}
+	if value, ok := destination.GetProperty("Password"); !ok || value != "secret" {
+		t.Errorf("Password is %q, expected %q", value, "secret")
+	}
+}
+
+func TestIsSecretProperty(t *testing.T) {
+	testCases := []struct {
+		key    string
+		secret bool
+	}{
+		{key: "Password", secret: true},
+		{key: "KeyStorePassword", secret: true},
+		{key: "tokenServicePassword", secret: true},
+		{key: "clientSecret", secret: true},
+		{key: "tokenService.body.client_secret", secret: true},
+		{key: "SystemUserToken", secret: true},
+		{key: "jco.client.passwd", secret: true},
+		{key: "JCO.CLIENT.PASSWD", secret: true},
+		{key: "jco.client.mysapsso2", secret: true},
+		{key: "jco.destination.repository.passwd", secret: true},
+		{key: "User", secret: false},
+		{key: "clientId", secret: false},
+		{key: "tokenServiceURL", secret: false},
+		{key: "jco.client.user", secret: false},
+		{key: "jco.client.ashost", secret: false},
+		{key: "jco.destination.repository.user", secret: false},
+		{key: "passwordPolicy", secret: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			if secret := IsSecretProperty(tc.key); secret != tc.secret {
+				t.Errorf("IsSecretProperty(%q) is %t, expected %t", tc.key, secret, tc.secret)
+			}
+		})
+	}
+}
+
+func TestDestinationConfigurationRedacted(t *testing.T) {
+	var destination DestinationConfiguration
+	input := `{"Name":"dest","Type":"RFC","jco.client.user":"user","jco.client.passwd":"secret","jco.destination.repository.passwd":""}`
+	if err := json.Unmarshal([]byte(input), &destination); err != nil {
+		t.Fatalf("could not unmarshal destination: %s", err)
+	}
+	data, err := json.Marshal(destination.Redacted())
+	if err != nil {
+		t.Fatalf("could not marshal destination: %s", err)
+	}
+	assertSameJSON(t, `{"Name":"dest","Type":"RFC","jco.client.user":"user","jco.client.passwd":"`+RedactedValue+`","jco.destination.repository.passwd":""}`, string(data))
+}
@@ -0,0 +1,6 @@
+package models
+
+// DestinationErrorResponse destination service error response
+type DestinationErrorResponse struct {
+	ErrorMessage string `json:"ErrorMessage,omitempty"`
+}
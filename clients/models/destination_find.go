@@ -0,0 +1,56 @@
+package models
+
+// DestinationFindResponse destination service find destination response
+type DestinationFindResponse struct {
+	Owner                    DestinationOwner         `json:"owner"`
+	DestinationConfiguration DestinationConfiguration `json:"destinationConfiguration"`
+	AuthTokens               []DestinationAuthToken   `json:"authTokens,omitempty"`
+	Certificates             []DestinationCertificate `json:"certificates,omitempty"`
+}
+
+// DestinationOwner owner of destination: subaccount or service instance
+type DestinationOwner struct {
+	SubaccountID string `json:"SubaccountId,omitempty"`
+	InstanceID   string `json:"InstanceId,omitempty"`
+}
+
+// DestinationAuthToken authentication token resolved by destination service
+type DestinationAuthToken struct {
+	Type       string                      `json:"type,omitempty"`
+	Value      string                      `json:"value,omitempty"`
+	HTTPHeader *DestinationAuthTokenHeader `json:"http_header,omitempty"`
+	ExpiresIn  string                      `json:"expires_in,omitempty"`
+	Error      string                      `json:"error,omitempty"`
+}
+
+// DestinationAuthTokenHeader HTTP header, that should be sent with authentication token
+type DestinationAuthTokenHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Redacted returns copy of find destination response with values
+// of destination secrets, tokens and certificates replaced by placeholder
+func (r DestinationFindResponse) Redacted() DestinationFindResponse {
+	r.DestinationConfiguration = r.DestinationConfiguration.Redacted()
+	authTokens := r.AuthTokens
+	r.AuthTokens = make([]DestinationAuthToken, 0, len(authTokens))
+	for _, authToken := range authTokens {
+		if authToken.Value != "" {
+			authToken.Value = RedactedValue
+		}
+		if authToken.HTTPHeader != nil {
+			authToken.HTTPHeader = &DestinationAuthTokenHeader{Key: authToken.HTTPHeader.Key, Value: RedactedValue}
+		}
+		r.AuthTokens = append(r.AuthTokens, authToken)
+	}
+	certificates := r.Certificates
+	r.Certificates = make([]DestinationCertificate, 0, len(certificates))
+	for _, certificate := range certificates {
+		if certificate.Content != "" {
+			certificate.Content = RedactedValue
+		}
+		r.Certificates = append(r.Certificates, certificate)
+	}
+	return r
+}
@@ -0,0 +1,7 @@
+package models
+
+// SCCErrorResponse Cloud Connector REST API error response
+type SCCErrorResponse struct {
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message,omitempty"`
+}
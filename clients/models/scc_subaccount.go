@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// Tunnel states of Cloud Connector subaccount
+const (
+	SCCTunnelConnected    = "Connected"
+	SCCTunnelConnecting   = "Connecting"
+	SCCTunnelDisconnected = "Disconnected"
+)
+
+// SCCSubaccount subaccount connection of Cloud Connector
+type SCCSubaccount struct {
+	RegionHost            string          `json:"regionHost"`
+	Subaccount            string          `json:"subaccount"`
+	LocationID            string          `json:"locationID,omitempty"`
+	DisplayName           string          `json:"displayName,omitempty"`
+	Description           string          `json:"description,omitempty"`
+	Tunnel                *SCCTunnel      `json:"tunnel,omitempty"`
+	SubaccountCertificate *SCCCertificate `json:"subaccountCertificate,omitempty"`
+}
+
+// SCCTunnel state of tunnel between Cloud Connector and subaccount
+type SCCTunnel struct {
+	State                   string                     `json:"state"`
+	ConnectedSinceTimeStamp int64                      `json:"connectedSinceTimeStamp,omitempty"`
+	Connections             int                        `json:"connections"`
+	User                    string                     `json:"user,omitempty"`
+	ApplicationConnections  []SCCApplicationConnection `json:"applicationConnections,omitempty"`
+	ServiceChannels         []SCCServiceChannel        `json:"serviceChannels,omitempty"`
+}
+
+// SCCApplicationConnection connection of cloud application through tunnel
+type SCCApplicationConnection struct {
+	ConnectionCount int    `json:"connectionCount"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+}
+
+// SCCServiceChannel service channel of tunnel
+type SCCServiceChannel struct {
+	Type    string `json:"type"`
+	State   string `json:"state"`
+	Details string `json:"details,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// SCCCertificate certificate managed by Cloud Connector. Time stamps are
+// milliseconds since epoch
+type SCCCertificate struct {
+	SubjectDN          string `json:"subjectDN"`
+	Issuer             string `json:"issuer"`
+	SerialNumber       string `json:"serialNumber,omitempty"`
+	NotBeforeTimeStamp int64  `json:"notBeforeTimeStamp"`
+	NotAfterTimeStamp  int64  `json:"notAfterTimeStamp"`
+}
+
+// ConnectedSince returns time tunnel is connected since or zero time
+func (t SCCTunnel) ConnectedSince() time.Time {
+	if t.ConnectedSinceTimeStamp == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(t.ConnectedSinceTimeStamp)
+}
+
+// NotAfter returns expiry time of certificate
+func (c SCCCertificate) NotAfter() time.Time {
+	return time.UnixMilli(c.NotAfterTimeStamp)
+}
@@ -0,0 +1,24 @@
+package models
+
+// SCCSystemMapping system mapping of Cloud Connector subaccount
+type SCCSystemMapping struct {
+	VirtualHost        string `json:"virtualHost"`
+	VirtualPort        string `json:"virtualPort"`
+	LocalHost          string `json:"localHost"`
+	LocalPort          string `json:"localPort"`
+	Protocol           string `json:"protocol"`
+	BackendType        string `json:"backendType,omitempty"`
+	AuthenticationMode string `json:"authenticationMode,omitempty"`
+	HostInHeader       string `json:"hostInHeader,omitempty"`
+	SNCPartnerName     string `json:"sncPartnerName,omitempty"`
+	Description        string `json:"description,omitempty"`
+}
+
+// SCCResource resource (URL path) of Cloud Connector system mapping
+type SCCResource struct {
+	ID                      string `json:"id"`
+	Enabled                 bool   `json:"enabled"`
+	ExactMatchOnly          bool   `json:"exactMatchOnly"`
+	WebsocketUpgradeAllowed bool   `json:"websocketUpgradeAllowed,omitempty"`
+	Description             string `json:"description,omitempty"`
+}
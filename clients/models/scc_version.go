@@ -0,0 +1,6 @@
+package models
+
+// SCCVersion Cloud Connector version response
+type SCCVersion struct {
+	Version string `json:"version"`
+}
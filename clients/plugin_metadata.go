@@ -0,0 +1,38 @@
+package clients
+
+import (
+	models "cf-cloud-connector/clients/models"
+)
+
+// Name prefix and metadata label of service keys and service instances
+// created by the plugin, which allow to find and clean them up
+const (
+	ServiceKeyNamePrefix = "cloud-connector-key-"
+	CreatedByLabel       = "created-by"
+	CreatedByLabelValue  = "cf-cloud-connector-plugin"
+)
+
+// RotatedKeyLabel label of service keys created by key rotation. Such keys
+// are used by applications and are not deleted by clean-up
+const RotatedKeyLabel = "rotated-key"
+
+// Label selectors of resources created by the plugin
+const (
+	PluginLabelSelector  = CreatedByLabel + "=" + CreatedByLabelValue
+	CleanupLabelSelector = PluginLabelSelector + ",!" + RotatedKeyLabel
+)
+
+// PluginMetadata metadata of resources created by the plugin
+func PluginMetadata() models.CFMetadata {
+	return models.CFMetadata{
+		Labels:      map[string]string{CreatedByLabel: CreatedByLabelValue},
+		Annotations: map[string]string{},
+	}
+}
+
+// RotatedKeyMetadata metadata of service keys created by key rotation
+func RotatedKeyMetadata() models.CFMetadata {
+	metadata := PluginMetadata()
+	metadata.Labels[RotatedKeyLabel] = "true"
+	return metadata
+}
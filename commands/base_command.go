@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"flag"
 	"fmt"
+	"io"
+
+	"cf-cloud-connector/ui"
 
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin"
@@ -103,3 +107,42 @@ func (c *BaseCommand) GetUsername() (string, error) {
 	}
 	return username, nil
 }
+
+// NewFlagSet creates flag set for parsing command arguments. Parse errors
+// are not printed and have to be reported by the command. Flag set
+// includes --output flag, common for all commands
+func (c *BaseCommand) NewFlagSet() *flag.FlagSet {
+	flagSet := flag.NewFlagSet(c.Name, flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	flagSet.Var(outputFormatValue{}, "output", "")
+	flagSet.Var(outputFormatValue{}, "o", "")
+	return flagSet
+}
+
+// outputFormatValue value of --output flag, which sets output format of ui
+type outputFormatValue struct{}
+
+func (outputFormatValue) String() string {
+	return ui.GetOutputFormat()
+}
+
+func (outputFormatValue) Set(value string) error {
+	return ui.SetOutputFormat(value)
+}
+
+// parseFlags parses command arguments, allowing positional arguments
+// to be mixed with flags, and returns positional arguments
+func parseFlags(flagSet *flag.FlagSet, args []string) ([]string, error) {
+	positional := make([]string, 0)
+	for {
+		if err := flagSet.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flagSet.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
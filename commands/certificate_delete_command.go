@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// CertificateDeleteCommand deletes certificates of destination service
+type CertificateDeleteCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *CertificateDeleteCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-cert-delete",
+		HelpText: "Delete destination service certificates on subaccount or destination service instance level",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-cert-delete CERTIFICATE_NAME [CERTIFICATE_NAME...] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [-f] [--output FORMAT]",
+			Options: map[string]string{
+				"CERTIFICATE_NAME":                  "Name of certificate to delete",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-destination-instance, -di":        "Delete certificates of destination service instance with specified name instead of subaccount certificates",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-force, -f":                        "Force deletion without confirmation",
+				"-output, -o":                       "Output format of result: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *CertificateDeleteCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var destinationInstanceName string
+	var force bool
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	flagSet.BoolVar(&force, "force", false, "")
+	flagSet.BoolVar(&force, "f", false, "")
+	names, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(names) == 0 {
+		ui.Failed("Certificate name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.DeleteCertificates(names, destinationInstanceName, force)
+}
+
+// DeleteCertificates deletes certificates on subaccount level or, if destination
+// service instance name is provided, on level of this service instance
+func (c *CertificateDeleteCommand) DeleteCertificates(names []string, destinationInstanceName string, force bool) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	level := "subaccount"
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		level = destinationInstanceName
+		levelMessage = " in destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	// Ask for confirmation
+	if !force && !ui.Confirm("Really delete certificates %s%s?", terminal.EntityNameColor(strings.Join(names, ", ")), levelMessage) {
+		ui.Warn("Delete cancelled")
+		return Success
+	}
+
+	ui.Say("Deleting certificates%s in org %s / space %s as %s...",
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Delete certificates
+	status := Success
+	output := ui.NewOutput([]string{"name", "level", "result"})
+	for _, name := range names {
+		err = deleteCertificate(destinationContext, destinationInstanceName != "", name)
+		if err != nil {
+			status = Failure
+			output.Add(name, level, terminal.FailureColor("failed: "+err.Error()))
+		} else {
+			output.Add(name, level, terminal.SuccessColor("deleted"))
+		}
+	}
+
+	if status == Success {
+		ui.Ok()
+	} else {
+		ui.Failed("Could not delete some of certificates")
+	}
+	ui.Say("")
+	if err = output.Print(nil); err != nil {
+		ui.Failed("Could not print result: %s", err.Error())
+		return Failure
+	}
+
+	return status
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"encoding/base64"
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// CertificateDownloadCommand downloads certificate file from destination service
+type CertificateDownloadCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *CertificateDownloadCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-cert-download",
+		HelpText: "Download certificate file from destination service on subaccount or destination service instance level",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-cert-download CERTIFICATE_NAME [-f FILE] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance]",
+			Options: map[string]string{
+				"CERTIFICATE_NAME":                  "Name of certificate in destination service",
+				"FILE":                              "Path to file, where certificate will be written",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-file, -f":                         "Write certificate to specified file. Default value is the name of certificate",
+				"-destination-instance, -di":        "Download certificate of destination service instance with specified name instead of subaccount certificate",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *CertificateDownloadCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var fileName string
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&fileName, "file", "", "")
+	flagSet.StringVar(&fileName, "f", "", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) == 0 {
+		ui.Failed("Certificate name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if len(positional) > 1 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if fileName == "" {
+		fileName = positional[0]
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.DownloadCertificate(positional[0], fileName, destinationInstanceName)
+}
+
+// DownloadCertificate writes certificate on subaccount level or, if destination
+// service instance name is provided, on level of this service instance to file
+func (c *CertificateDownloadCommand) DownloadCertificate(certificateName string, fileName string, destinationInstanceName string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Downloading certificate %s to %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(certificateName),
+		terminal.EntityNameColor(fileName),
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get certificate
+	destinationCertificate, err := getCertificate(destinationContext, destinationInstanceName != "", certificateName)
+	if err != nil {
+		ui.Failed("Could not get certificate %s: %s", certificateName, err.Error())
+		return Failure
+	}
+	content, err := base64.StdEncoding.DecodeString(destinationCertificate.Content)
+	if err != nil {
+		ui.Failed("Could not decode content of certificate %s: %s", certificateName, err.Error())
+		return Failure
+	}
+
+	// Write file. Key stores may contain private keys, so file is
+	// readable only by current user
+	err = os.WriteFile(fileName, content, 0600)
+	if err != nil {
+		ui.Failed("Could not write file %s: %s", fileName, err.Error())
+		return Failure
+	}
+
+	ui.Ok()
+	return Success
+}
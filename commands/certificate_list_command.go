@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"cf-cloud-connector/certificates"
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// CertificateListCommand prints the list of subaccount or service instance
+// level certificates of destination service
+type CertificateListCommand struct {
+	DestinationCommand
+}
+
+// States of parsed certificate files
+const (
+	certificateStatusParsed    = "parsed"
+	certificateStatusNotParsed = "not parsed"
+	certificateStatusError     = "error"
+)
+
+// CertificateInfo parsed X.509 certificate of certificate file. Key stores,
+// which can not be read without matching password, have status 'not parsed'
+type CertificateInfo struct {
+	Name     string `json:"name"`
+	Format   string `json:"format"`
+	Status   string `json:"status"`
+	Subject  string `json:"subject,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+	NotAfter string `json:"notAfter,omitempty"`
+	DaysLeft *int   `json:"daysLeft,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *CertificateListCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-cert-list",
+		HelpText: "Display list of destination service certificates with subject, issuer and expiry",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-cert-list [--password PASSWORD] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"PASSWORD":                          "Password of PKCS#12 key stores (.p12, .pfx)",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-password":                         "Read certificates of PKCS#12 key stores with specified password. Key stores, which can not be read without password or with specified password, are listed as 'not parsed'",
+				"-destination-instance, -di":        "List certificates of destination service instance with specified name instead of subaccount certificates",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *CertificateListCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var password string
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&password, "password", "", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.ListCertificates(destinationInstanceName, password)
+}
+
+// ListCertificates prints certificates on subaccount level or, if destination
+// service instance name is provided, on level of this service instance.
+// Password is used to read PKCS#12 key stores
+func (c *CertificateListCommand) ListCertificates(destinationInstanceName string, password string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		levelMessage = " in destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	ui.Say("Getting list of certificates%s in org %s / space %s as %s...",
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get certificates
+	destinationCertificates, err := listCertificates(destinationContext, destinationInstanceName != "")
+	if err != nil {
+		ui.Failed("Could not get list of certificates: %s", err.Error())
+		return Failure
+	}
+
+	// Parse certificates. Content is fetched separately, if list
+	// does not contain it
+	infos := make([]CertificateInfo, 0)
+	for _, destinationCertificate := range destinationCertificates {
+		if destinationCertificate.Content == "" {
+			fullCertificate, err := getCertificate(destinationContext, destinationInstanceName != "", destinationCertificate.Name)
+			if err != nil {
+				infos = append(infos, CertificateInfo{Name: destinationCertificate.Name, Format: "-", Status: certificateStatusError, Error: err.Error()})
+				continue
+			}
+			destinationCertificate = fullCertificate
+		}
+		infos = append(infos, parseDestinationCertificate(destinationCertificate, password, time.Now())...)
+	}
+
+	ui.Ok()
+	ui.Say("")
+
+	// Display information about certificates
+	output := ui.NewOutput([]string{"name", "format", "subject", "issuer", "expires", "days left"})
+	for _, info := range infos {
+		switch info.Status {
+		case certificateStatusNotParsed:
+			output.Add(info.Name, info.Format, terminal.WarningColor(info.Status+": "+info.Error), "-", "-", "-")
+			continue
+		case certificateStatusError:
+			output.Add(info.Name, info.Format, terminal.FailureColor(info.Error), "-", "-", "-")
+			continue
+		}
+		daysLeft := strconv.Itoa(*info.DaysLeft)
+		if *info.DaysLeft < 0 {
+			daysLeft = terminal.FailureColor(daysLeft)
+		}
+		output.Add(info.Name, info.Format, info.Subject, info.Issuer, info.NotAfter, daysLeft)
+	}
+	if err = output.Print(infos); err != nil {
+		ui.Failed("Could not print certificates: %s", err.Error())
+		return Failure
+	}
+
+	return Success
+}
+
+// parseDestinationCertificate parses certificates of destination service
+// certificate file, PKCS#12 key stores with password. Single item with
+// error is returned, if file can not be parsed
+func parseDestinationCertificate(destinationCertificate models.DestinationCertificate, password string, now time.Time) []CertificateInfo {
+	content, err := base64.StdEncoding.DecodeString(destinationCertificate.Content)
+	if err != nil {
+		return []CertificateInfo{{Name: destinationCertificate.Name, Format: "-", Status: certificateStatusError, Error: "invalid content: " + err.Error()}}
+	}
+	format := certificates.DetectFormat(destinationCertificate.Name, content)
+	parsedCertificates, err := certificates.ParseWithPassword(destinationCertificate.Name, content, password)
+	if err == certificates.ErrPasswordRequired || err == certificates.ErrInvalidPassword {
+		return []CertificateInfo{{Name: destinationCertificate.Name, Format: format, Status: certificateStatusNotParsed, Error: err.Error()}}
+	}
+	if err != nil {
+		return []CertificateInfo{{Name: destinationCertificate.Name, Format: format, Status: certificateStatusError, Error: err.Error()}}
+	}
+	infos := make([]CertificateInfo, 0, len(parsedCertificates))
+	for _, parsedCertificate := range parsedCertificates {
+		daysLeft := certificates.DaysUntilExpiry(parsedCertificate, now)
+		infos = append(infos, CertificateInfo{
+			Name:     destinationCertificate.Name,
+			Format:   format,
+			Status:   certificateStatusParsed,
+			Subject:  parsedCertificate.Subject.String(),
+			Issuer:   parsedCertificate.Issuer.String(),
+			NotAfter: parsedCertificate.NotAfter.UTC().Format(time.RFC3339),
+			DaysLeft: &daysLeft,
+		})
+	}
+	return infos
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"cf-cloud-connector/certificates"
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// CertificateUploadCommand uploads certificate file to destination service
+type CertificateUploadCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *CertificateUploadCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-cert-upload",
+		HelpText: "Upload PEM, P12 or JKS certificate file to destination service on subaccount or destination service instance level",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-cert-upload FILE [-n CERTIFICATE_NAME] [--password PASSWORD] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"FILE":                              "Path to certificate file (.pem, .crt, .cer, .der, .p12, .pfx or .jks)",
+				"CERTIFICATE_NAME":                  "Name of certificate in destination service, including extension, e.g. client.p12",
+				"PASSWORD":                          "Password of PKCS#12 key store (.p12, .pfx)",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-name, -n":                         "Upload certificate with specified name. Default value is the name of file",
+				"-password":                         "Check certificates of PKCS#12 key store with specified password before upload. Key store is uploaded as is",
+				"-destination-instance, -di":        "Upload certificate on level of destination service instance with specified name instead of subaccount level",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of uploaded certificate: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *CertificateUploadCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var certificateName string
+	var password string
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&certificateName, "name", "", "")
+	flagSet.StringVar(&certificateName, "n", "", "")
+	flagSet.StringVar(&password, "password", "", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) == 0 {
+		ui.Failed("Certificate file is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if len(positional) > 1 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if certificateName == "" {
+		certificateName = filepath.Base(positional[0])
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.UploadCertificate(positional[0], certificateName, password, destinationInstanceName)
+}
+
+// UploadCertificate uploads certificate file on subaccount level or, if
+// destination service instance name is provided, on level of this service instance.
+// Password is used to check certificates of PKCS#12 key stores
+func (c *CertificateUploadCommand) UploadCertificate(fileName string, certificateName string, password string, destinationInstanceName string) ExecutionStatus {
+	// Read certificate file
+	log.Tracef("Reading certificate file %s\n", fileName)
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		ui.Failed("Could not read certificate file %s: %s", fileName, err.Error())
+		return Failure
+	}
+	if _, err = certificates.ParseWithPassword(fileName, content, password); err != nil {
+		if err != certificates.ErrPasswordRequired {
+			ui.Failed("Could not parse certificate file %s: %s", fileName, err.Error())
+			return Failure
+		}
+		ui.Warn("Certificates of %s are not checked: %s. Use --password to check them", fileName, err.Error())
+	}
+	if !strings.Contains(certificateName, ".") {
+		ui.Warn("Certificate name %s has no extension, destination service may not recognize format of certificate", certificateName)
+	}
+
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		levelMessage = " in destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	ui.Say("Uploading certificate %s%s in org %s / space %s as %s...",
+		terminal.EntityNameColor(certificateName),
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Upload certificate
+	destinationCertificate := models.DestinationCertificate{
+		Name:    certificateName,
+		Type:    "CERTIFICATE",
+		Content: base64.StdEncoding.EncodeToString(content),
+	}
+	err = createCertificate(destinationContext, destinationInstanceName != "", destinationCertificate)
+	if err != nil {
+		ui.Failed("Could not upload certificate %s: %s", certificateName, err.Error())
+		return Failure
+	}
+
+	ui.Ok()
+	ui.Say("")
+
+	// Display uploaded certificate
+	infos := parseDestinationCertificate(destinationCertificate, password, time.Now())
+	output := ui.NewOutput([]string{"name", "format", "subject", "issuer", "expires"})
+	for _, info := range infos {
+		switch info.Status {
+		case certificateStatusNotParsed:
+			output.Add(info.Name, info.Format, terminal.WarningColor(info.Status), "-", "-")
+		case certificateStatusError:
+			output.Add(info.Name, info.Format, "-", "-", "-")
+		default:
+			output.Add(info.Name, info.Format, info.Subject, info.Issuer, info.NotAfter)
+		}
+	}
+	if err = output.Print(infos); err != nil {
+		ui.Failed("Could not print certificate: %s", err.Error())
+		return Failure
+	}
+
+	return Success
+}
@@ -0,0 +1,438 @@
+package commands
+
+import (
+	"cf-cloud-connector/certificates"
+	"cf-cloud-connector/clients"
+	"cf-cloud-connector/clients/connector"
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// Sources of checked certificates
+const (
+	expirySourceSCC          = "scc"
+	expirySourceDestinations = "destinations"
+	expirySourceServiceKeys  = "service-keys"
+)
+
+// Expiry states of checked certificates
+const (
+	expiryStatusOK       = "ok"
+	expiryStatusWarning  = "warning"
+	expiryStatusExpiring = "expiring"
+	expiryStatusExpired  = "expired"
+	expiryStatusError    = "error"
+	expiryStatusUnknown  = "unknown"
+)
+
+// CertsExpiryCommand reports expiry of Cloud Connector certificates,
+// destination service certificates and x509 service key certificates
+type CertsExpiryCommand struct {
+	SCCCommand
+}
+
+// CertificateExpiry expiry of certificate of one of checked sources
+type CertificateExpiry struct {
+	Source   string `json:"source"`
+	Owner    string `json:"owner"`
+	Name     string `json:"name"`
+	Subject  string `json:"subject,omitempty"`
+	NotAfter string `json:"notAfter,omitempty"`
+	DaysLeft *int   `json:"daysLeft,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *CertsExpiryCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-certs-expiry",
+		HelpText: "Report expiry of Cloud Connector, destination service and service key certificates. Fails, if any certificate expires within specified number of days. Expiry of PKCS#12 key stores, which can not be read without password or with specified password, is reported as unknown",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-certs-expiry [--days N] [--warn-days N] [--sources SOURCES] [--password PASSWORD] [--fail-on-unknown] " + sccUsage + " [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: withSCCUsageOptions(map[string]string{
+				"SOURCES":                           "Comma-separated list of checked sources: 'scc', 'destinations' and 'service-keys'. By default all sources are checked, 'scc' only if Cloud Connector URL is provided",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-days":                             "Fail, if any certificate expires within specified number of days. Default value is 30",
+				"-warn-days":                        "Mark certificates, which expire within specified number of days, as warnings. Default value is 60",
+				"-sources":                          "Check only certificates of specified sources",
+				"-password":                         "Read destination service certificates of PKCS#12 key stores with specified password",
+				"-fail-on-unknown":                  "Fail, if expiry of any certificate is unknown, e.g. of PKCS#12 key store, which can not be read",
+				"-destination-instance, -di":        "Check only subaccount certificates and certificates of destination service instance with specified name",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of report: table, json, yaml or csv. Default value is 'table'",
+			}),
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *CertsExpiryCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var days int
+	var warnDays int
+	var sourcesList string
+	var destinationInstanceName string
+	var password string
+	var failOnUnknown bool
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	c.addSCCFlags(flagSet)
+	flagSet.IntVar(&days, "days", 30, "")
+	flagSet.IntVar(&warnDays, "warn-days", 60, "")
+	flagSet.StringVar(&sourcesList, "sources", "", "")
+	flagSet.StringVar(&password, "password", "", "")
+	flagSet.BoolVar(&failOnUnknown, "fail-on-unknown", false, "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if days < 0 || warnDays < days {
+		ui.Failed("Invalid thresholds: --days must not be negative and --warn-days must not be less than --days")
+		return Failure
+	}
+
+	// Select sources
+	sources := []string{expirySourceDestinations, expirySourceServiceKeys}
+	if c.sccURL != "" {
+		sources = append([]string{expirySourceSCC}, sources...)
+	}
+	if sourcesList != "" {
+		sources = strings.Split(sourcesList, ",")
+		for idx, source := range sources {
+			sources[idx] = strings.TrimSpace(source)
+			if sources[idx] != expirySourceSCC && sources[idx] != expirySourceDestinations && sources[idx] != expirySourceServiceKeys {
+				ui.Failed("Invalid source %q. Use 'scc', 'destinations' or 'service-keys'", source)
+				return Failure
+			}
+		}
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.ReportExpiry(sources, days, warnDays, destinationInstanceName, password, failOnUnknown)
+}
+
+// ReportExpiry prints expiry of certificates of sources and fails, if any
+// certificate expires within days or can not be checked. Password is used
+// to read PKCS#12 key stores of destination service. Certificates with
+// unknown expiry fail the report only if failOnUnknown is set
+func (c *CertsExpiryCommand) ReportExpiry(sources []string, days int, warnDays int, destinationInstanceName string, password string, failOnUnknown bool) ExecutionStatus {
+	now := time.Now()
+	expiries := make([]CertificateExpiry, 0)
+
+	// Check Cloud Connector certificates
+	if indexOfString(sources, expirySourceSCC) >= 0 {
+		client, err := c.NewConnectorClient()
+		if err != nil {
+			ui.Failed(err.Error())
+			return Failure
+		}
+		ui.Say("Checking certificates of Cloud Connector %s as %s...",
+			terminal.EntityNameColor(client.URL),
+			terminal.EntityNameColor(client.User))
+		sccExpiries, err := getSCCCertificatesExpiry(client, now)
+		if err != nil {
+			ui.Failed(err.Error())
+			return Failure
+		}
+		expiries = append(expiries, sccExpiries...)
+	}
+
+	// Check certificates of Cloud Foundry space
+	checkDestinations := indexOfString(sources, expirySourceDestinations) >= 0
+	checkServiceKeys := indexOfString(sources, expirySourceServiceKeys) >= 0
+	if checkDestinations || checkServiceKeys {
+		log.Tracef("Getting context (org/space/username)\n")
+		context, err := c.GetContext()
+		if err != nil {
+			ui.Failed("Could not get org and space: %s", err.Error())
+			return Failure
+		}
+
+		ui.Say("Checking certificates in org %s / space %s as %s...",
+			terminal.EntityNameColor(context.Org),
+			terminal.EntityNameColor(context.Space),
+			terminal.EntityNameColor(context.Username))
+
+		if checkDestinations {
+			destinationExpiries, err := c.getDestinationCertificatesExpiry(context, destinationInstanceName, password, now)
+			if err != nil {
+				ui.Failed(err.Error())
+				return Failure
+			}
+			expiries = append(expiries, destinationExpiries...)
+		}
+		if checkServiceKeys {
+			serviceKeyExpiries, err := c.getServiceKeyCertificatesExpiry(context, now)
+			if err != nil {
+				ui.Failed(err.Error())
+				return Failure
+			}
+			expiries = append(expiries, serviceKeyExpiries...)
+		}
+	}
+
+	// Classify certificates, the ones expiring first are listed first
+	failed := 0
+	warnings := 0
+	unknown := 0
+	for idx := range expiries {
+		expiry := &expiries[idx]
+		switch {
+		case expiry.Status == expiryStatusUnknown:
+			unknown++
+		case expiry.Error != "":
+			expiry.Status = expiryStatusError
+			failed++
+		case *expiry.DaysLeft < 0:
+			expiry.Status = expiryStatusExpired
+			failed++
+		case *expiry.DaysLeft <= days:
+			expiry.Status = expiryStatusExpiring
+			failed++
+		case *expiry.DaysLeft <= warnDays:
+			expiry.Status = expiryStatusWarning
+			warnings++
+		default:
+			expiry.Status = expiryStatusOK
+		}
+	}
+	sort.SliceStable(expiries, func(i, j int) bool {
+		if expiries[i].DaysLeft == nil || expiries[j].DaysLeft == nil {
+			return expiries[i].DaysLeft == nil && expiries[j].DaysLeft != nil
+		}
+		return *expiries[i].DaysLeft < *expiries[j].DaysLeft
+	})
+
+	if failed > 0 {
+		ui.Failed("%d certificates are expired, expire within %d days or could not be checked", failed, days)
+	} else if failOnUnknown && unknown > 0 {
+		ui.Failed("Expiry of %d certificates is unknown", unknown)
+	} else {
+		ui.Ok()
+	}
+	ui.Say("")
+
+	// Display report
+	output := ui.NewOutput([]string{"source", "owner", "name", "subject", "expires", "days left", "status"})
+	for _, expiry := range expiries {
+		status := expiry.Status
+		switch status {
+		case expiryStatusOK:
+			status = terminal.SuccessColor(status)
+		case expiryStatusWarning:
+			status = terminal.WarningColor(status)
+		case expiryStatusUnknown:
+			if failOnUnknown {
+				status = terminal.FailureColor(status)
+			} else {
+				status = terminal.WarningColor(status)
+			}
+		default:
+			status = terminal.FailureColor(status)
+		}
+		if expiry.Status == expiryStatusUnknown {
+			output.Add(expiry.Source, expiry.Owner, expiry.Name, terminal.WarningColor(expiry.Error), "-", "-", status)
+			continue
+		}
+		if expiry.Error != "" {
+			output.Add(expiry.Source, expiry.Owner, expiry.Name, terminal.FailureColor(expiry.Error), "-", "-", status)
+			continue
+		}
+		output.Add(expiry.Source, expiry.Owner, expiry.Name, expiry.Subject, expiry.NotAfter, strconv.Itoa(*expiry.DaysLeft), status)
+	}
+	if err := output.Print(expiries); err != nil {
+		ui.Failed("Could not print report: %s", err.Error())
+		return Failure
+	}
+	ui.Say("")
+	ui.Say("%d certificates checked, %d failed, %d warnings, %d unknown", len(expiries), failed, warnings, unknown)
+
+	if failed > 0 || (failOnUnknown && unknown > 0) {
+		return Failure
+	}
+	return Success
+}
+
+// getSCCCertificatesExpiry returns expiry of UI, system, CA and subaccount
+// certificates of Cloud Connector. Certificates, which are not configured,
+// are skipped
+func getSCCCertificatesExpiry(client *connector.Client, now time.Time) ([]CertificateExpiry, error) {
+	expiries := make([]CertificateExpiry, 0)
+	for _, item := range []struct {
+		name string
+		get  func() (models.SCCCertificate, error)
+	}{
+		{"UI certificate", client.GetUICertificate},
+		{"system certificate", client.GetSystemCertificate},
+		{"CA certificate", client.GetCACertificate},
+	} {
+		certificate, err := item.get()
+		if connector.IsNotFound(err) {
+			log.Tracef("Cloud Connector %s is not configured\n", item.name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		expiries = append(expiries, newSCCCertificateExpiry("connector", item.name, certificate, now))
+	}
+
+	subaccounts, err := client.GetSubaccounts()
+	if err != nil {
+		return nil, err
+	}
+	for _, subaccount := range subaccounts {
+		details, err := client.GetSubaccount(subaccount.RegionHost, subaccount.Subaccount)
+		if err != nil {
+			return nil, err
+		}
+		if details.SubaccountCertificate != nil {
+			expiries = append(expiries, newSCCCertificateExpiry("subaccount "+subaccount.Subaccount, "subaccount certificate", *details.SubaccountCertificate, now))
+		}
+	}
+	return expiries, nil
+}
+
+// newSCCCertificateExpiry returns expiry of Cloud Connector certificate
+func newSCCCertificateExpiry(owner string, name string, certificate models.SCCCertificate, now time.Time) CertificateExpiry {
+	daysLeft := int(math.Floor(certificate.NotAfter().Sub(now).Hours() / 24))
+	return CertificateExpiry{
+		Source:   expirySourceSCC,
+		Owner:    owner,
+		Name:     name,
+		Subject:  certificate.SubjectDN,
+		NotAfter: certificate.NotAfter().UTC().Format(time.RFC3339),
+		DaysLeft: &daysLeft,
+	}
+}
+
+// getDestinationCertificatesExpiry returns expiry of subaccount certificates
+// and certificates of all destination service instances in the space or,
+// if destination service instance name is provided, only of this service
+// instance. Subaccount certificates are checked only once
+func (c *CertsExpiryCommand) getDestinationCertificatesExpiry(context Context, destinationInstanceName string, password string, now time.Time) ([]CertificateExpiry, error) {
+	var destinationContexts []DestinationContext
+	if destinationInstanceName != "" {
+		destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+		if err != nil {
+			return nil, err
+		}
+		destinationContexts = []DestinationContext{destinationContext}
+	} else {
+		var err error
+		destinationContexts, err = c.GetDestinationContexts(context)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	expiries := make([]CertificateExpiry, 0)
+	for idx, destinationContext := range destinationContexts {
+		instanceName := destinationContext.GetServiceInstance().Name
+		for _, instanceLevel := range []bool{false, true} {
+			// Subaccount certificates are the same via every service instance
+			if !instanceLevel && idx > 0 {
+				continue
+			}
+			owner := SubaccountSource
+			if instanceLevel {
+				owner = instanceName
+			}
+			destinationCertificates, err := listCertificates(destinationContext, instanceLevel)
+			if err != nil {
+				return nil, fmt.Errorf("could not get list of certificates of %s: %s", owner, err.Error())
+			}
+			for _, destinationCertificate := range destinationCertificates {
+				if destinationCertificate.Content == "" {
+					fullCertificate, err := getCertificate(destinationContext, instanceLevel, destinationCertificate.Name)
+					if err != nil {
+						expiries = append(expiries, CertificateExpiry{Source: expirySourceDestinations, Owner: owner, Name: destinationCertificate.Name, Error: err.Error()})
+						continue
+					}
+					destinationCertificate = fullCertificate
+				}
+				for _, info := range parseDestinationCertificate(destinationCertificate, password, now) {
+					expiry := CertificateExpiry{
+						Source:   expirySourceDestinations,
+						Owner:    owner,
+						Name:     info.Name,
+						Subject:  info.Subject,
+						NotAfter: info.NotAfter,
+						DaysLeft: info.DaysLeft,
+						Error:    info.Error,
+					}
+					// Expiry of key stores, which can not be read without
+					// password or with provided password, is unknown
+					if info.Status == certificateStatusNotParsed {
+						expiry.Status = expiryStatusUnknown
+					}
+					expiries = append(expiries, expiry)
+				}
+			}
+		}
+	}
+	return expiries, nil
+}
+
+// getServiceKeyCertificatesExpiry returns expiry of x509 certificates of
+// service keys of destination and connectivity service instances. Service
+// keys with other credential types are skipped
+func (c *CertsExpiryCommand) getServiceKeyCertificatesExpiry(context Context, now time.Time) ([]CertificateExpiry, error) {
+	serviceInstances, err := c.findRotatedServiceInstances(context, nil)
+	if err != nil {
+		return nil, err
+	}
+	expiries := make([]CertificateExpiry, 0)
+	for _, serviceInstance := range serviceInstances {
+		log.Tracef("Getting service keys of %s service instance\n", serviceInstance.Name)
+		serviceKeys, err := clients.GetServiceKeys(c.CliConnection, serviceInstance.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get service keys of %s service instance: %s", serviceInstance.Name, err.Error())
+		}
+		for _, serviceKey := range serviceKeys {
+			uaa := serviceKey.Credentials.UAA
+			if uaa == nil || uaa.Certificate == "" {
+				continue
+			}
+			expiry := CertificateExpiry{Source: expirySourceServiceKeys, Owner: serviceInstance.Name, Name: serviceKey.Name}
+			parsedCertificates, err := certificates.Parse(serviceKey.Name+".pem", []byte(uaa.Certificate))
+			if err == nil && len(parsedCertificates) == 0 {
+				err = fmt.Errorf("no certificate found")
+			}
+			if err != nil {
+				expiry.Error = "invalid certificate: " + err.Error()
+				expiries = append(expiries, expiry)
+				continue
+			}
+			// The first certificate of chain is the one of service key
+			daysLeft := certificates.DaysUntilExpiry(parsedCertificates[0], now)
+			expiry.Subject = parsedCertificates[0].Subject.String()
+			expiry.NotAfter = parsedCertificates[0].NotAfter.UTC().Format(time.RFC3339)
+			expiry.DaysLeft = &daysLeft
+			expiries = append(expiries, expiry)
+		}
+	}
+	return expiries, nil
+}
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients"
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// CleanupCommand deletes service keys and service instances, which were
+// created by the plugin in the current space and are recognized by label
+type CleanupCommand struct {
+	DestinationCommand
+}
+
+// CleanupItem service key or service instance created by the plugin
+type CleanupItem struct {
+	Type            string `json:"type"`
+	Name            string `json:"name"`
+	GUID            string `json:"guid"`
+	ServiceInstance string `json:"serviceInstance"`
+	Result          string `json:"result"`
+}
+
+// Types of clean-up items
+const (
+	cleanupServiceKey      = "service key"
+	cleanupServiceInstance = "service instance"
+)
+
+// GetPluginCommand returns the plugin command details
+func (c *CleanupCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-cleanup",
+		HelpText: "Delete service keys and app-runtime service instances created by the plugin in the space",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-cleanup [--dry-run] [-f] [--output FORMAT]",
+			Options: map[string]string{
+				"-dry-run":    "Only list service keys and service instances, which would be deleted",
+				"-force, -f":  "Force deletion without confirmation",
+				"-output, -o": "Output format of result: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *CleanupCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var dryRun bool
+	var force bool
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	flagSet.BoolVar(&dryRun, "dry-run", false, "")
+	flagSet.BoolVar(&force, "force", false, "")
+	flagSet.BoolVar(&force, "f", false, "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	return c.Cleanup(dryRun, force)
+}
+
+// Cleanup deletes service keys and service instances labeled as created
+// by the plugin. Rotated service keys are kept, unless they belong to
+// plugin service instances, which keys are deleted before service instances
+func (c *CleanupCommand) Cleanup(dryRun bool, force bool) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Looking for service keys and service instances created by the plugin in org %s / space %s as %s...",
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	items, err := c.findCleanupItems(context)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	ui.Ok()
+	ui.Say("")
+
+	if len(items) == 0 {
+		ui.Say("No service keys or service instances created by the plugin found")
+		if ui.IsMachineOutput() {
+			return c.printCleanupItems(items)
+		}
+		return Success
+	}
+
+	// Print plan on dry run
+	if dryRun {
+		for idx := range items {
+			items[idx].Result = "to be deleted"
+		}
+		return c.printCleanupItems(items)
+	}
+
+	// Ask for confirmation
+	if !force && !ui.Confirm("Really delete %d service keys and service instances created by the plugin?", len(items)) {
+		ui.Warn("Cleanup cancelled")
+		return Success
+	}
+
+	// Delete service keys first, as service instances with service keys
+	// can not be deleted
+	status := Success
+	for idx, item := range items {
+		if item.Type == cleanupServiceKey {
+			err = clients.DeleteServiceKey(c.CliConnection, item.GUID, maxRetryCount)
+		} else {
+			err = clients.DeleteServiceInstance(c.CliConnection, item.GUID, maxRetryCount)
+		}
+		if err != nil {
+			status = Failure
+			items[idx].Result = "failed: " + err.Error()
+		} else {
+			items[idx].Result = "deleted"
+		}
+	}
+
+	if status == Success {
+		ui.Ok()
+	} else {
+		ui.Failed("Could not delete some of service keys and service instances")
+	}
+	ui.Say("")
+	if c.printCleanupItems(items) == Failure {
+		return Failure
+	}
+
+	return status
+}
+
+// findCleanupItems finds labeled service keys of all service instances in
+// the space, labeled service instances and their service keys. Service keys
+// are listed before service instances
+func (c *CleanupCommand) findCleanupItems(context Context) ([]CleanupItem, error) {
+	// Get service instances of the space
+	serviceInstances, err := clients.GetServiceInstancesByLabel(c.CliConnection, context.SpaceID, "")
+	if err != nil {
+		return nil, err
+	}
+	serviceInstanceNames := make(map[string]string)
+	serviceInstanceGUIDs := make([]string, 0, len(serviceInstances))
+	for _, serviceInstance := range serviceInstances {
+		serviceInstanceNames[serviceInstance.GUID] = serviceInstance.Name
+		serviceInstanceGUIDs = append(serviceInstanceGUIDs, serviceInstance.GUID)
+	}
+	pluginServiceInstances, err := clients.GetServiceInstancesByLabel(c.CliConnection, context.SpaceID, clients.PluginLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	pluginServiceInstanceGUIDs := make([]string, 0, len(pluginServiceInstances))
+	for _, serviceInstance := range pluginServiceInstances {
+		pluginServiceInstanceGUIDs = append(pluginServiceInstanceGUIDs, serviceInstance.GUID)
+	}
+
+	// Get service keys
+	serviceKeys, err := clients.GetServiceKeysByLabel(c.CliConnection, serviceInstanceGUIDs, clients.CleanupLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	pluginServiceInstanceKeys, err := clients.GetServiceKeysByLabel(c.CliConnection, pluginServiceInstanceGUIDs, "")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]CleanupItem, 0)
+	serviceKeyGUIDs := make(map[string]bool)
+	for _, serviceKey := range append(serviceKeys, pluginServiceInstanceKeys...) {
+		if serviceKeyGUIDs[serviceKey.GUID] {
+			continue
+		}
+		serviceKeyGUIDs[serviceKey.GUID] = true
+		items = append(items, newServiceKeyCleanupItem(serviceKey, serviceInstanceNames[serviceKey.ServiceInstanceGUID]))
+	}
+	for _, serviceInstance := range pluginServiceInstances {
+		items = append(items, CleanupItem{
+			Type:            cleanupServiceInstance,
+			Name:            serviceInstance.Name,
+			GUID:            serviceInstance.GUID,
+			ServiceInstance: serviceInstance.Name,
+		})
+	}
+
+	return items, nil
+}
+
+func newServiceKeyCleanupItem(serviceKey models.CFServiceKey, serviceInstanceName string) CleanupItem {
+	return CleanupItem{
+		Type:            cleanupServiceKey,
+		Name:            serviceKey.Name,
+		GUID:            serviceKey.GUID,
+		ServiceInstance: serviceInstanceName,
+	}
+}
+
+// printCleanupItems prints clean-up items with their results
+func (c *CleanupCommand) printCleanupItems(items []CleanupItem) ExecutionStatus {
+	output := ui.NewOutput([]string{"type", "name", "service instance", "result"})
+	for _, item := range items {
+		result := item.Result
+		switch result {
+		case "deleted":
+			result = terminal.SuccessColor(result)
+		case "to be deleted":
+			result = terminal.WarningColor(result)
+		default:
+			result = terminal.FailureColor(result)
+		}
+		output.Add(item.Type, item.Name, item.ServiceInstance, result)
+	}
+	if err := output.Print(items); err != nil {
+		ui.Failed("Could not print result: %s", err.Error())
+		return Failure
+	}
+	return Success
+}
@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients"
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// ConnectivityCommand prints connectivity service instances, their plans
+// and service keys with on-premise proxy settings and verifies that access
+// token can be obtained
+type ConnectivityCommand struct {
+	DestinationCommand
+}
+
+// ConnectivityInfo on-premise proxy settings of connectivity service key
+type ConnectivityInfo struct {
+	ServiceInstance string `json:"serviceInstance"`
+	Plan            string `json:"plan"`
+	ServiceKey      string `json:"serviceKey"`
+	Temporary       bool   `json:"temporary,omitempty"`
+	ProxyHost       string `json:"proxyHost"`
+	HTTPPort        string `json:"httpPort"`
+	SOCKS5Port      string `json:"socks5Port"`
+	RFCPort         string `json:"rfcPort"`
+	TokenServiceURL string `json:"tokenServiceURL"`
+	Token           string `json:"token"`
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *ConnectivityCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-connectivity",
+		HelpText: "Display connectivity service instances, plans and service keys with on-premise proxy settings and verify access tokens",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-connectivity [-si SERVICE_INSTANCE_NAME] [--output FORMAT]",
+			Options: map[string]string{
+				"SERVICE_INSTANCE_NAME":  "Name of connectivity service instance",
+				"-service-instance, -si": "Display only connectivity service instance with specified name",
+				"-output, -o":            "Output format: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *ConnectivityCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var serviceInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	flagSet.StringVar(&serviceInstanceName, "service-instance", "", "")
+	flagSet.StringVar(&serviceInstanceName, "si", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.InspectConnectivity(serviceInstanceName)
+}
+
+// InspectConnectivity prints on-premise proxy settings of service keys of
+// connectivity service instances and verifies access tokens. Temporary
+// service key is created for service instances without service keys
+func (c *ConnectivityCommand) InspectConnectivity(serviceInstanceName string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Inspecting connectivity service instances in org %s / space %s as %s...",
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Find service instances
+	servicePlans, serviceInstances, err := c.findConnectivityServiceInstances(context, serviceInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+	planNames := make(map[string]string)
+	for _, servicePlan := range servicePlans {
+		planNames[servicePlan.GUID] = servicePlan.Name
+	}
+
+	// Inspect service keys
+	status := Success
+	infos := make([]ConnectivityInfo, 0)
+	for _, serviceInstance := range serviceInstances {
+		log.Tracef("Getting service keys of %s service instance\n", serviceInstance.Name)
+		serviceKeys, err := clients.GetServiceKeys(c.CliConnection, serviceInstance.GUID)
+		if err != nil {
+			ui.Failed("Could not get service keys of %s service instance: %s", serviceInstance.Name, err.Error())
+			return Failure
+		}
+		temporary := false
+		if len(serviceKeys) == 0 {
+			serviceKey, err := c.createTemporaryServiceKey(serviceInstance)
+			if err != nil {
+				ui.Failed("Could not create service key of %s service instance: %s", serviceInstance.Name, err.Error())
+				return Failure
+			}
+			serviceKeys = append(serviceKeys, *serviceKey)
+			temporary = true
+		}
+		for _, serviceKey := range serviceKeys {
+			info := newConnectivityInfo(serviceInstance, planNames[serviceInstance.ServicePlanGUID], serviceKey)
+			info.Temporary = temporary
+			if err = verifyServiceKey(serviceKey); err != nil {
+				info.Token = "failed: " + err.Error()
+				status = Failure
+			}
+			infos = append(infos, info)
+		}
+	}
+
+	if status == Success {
+		ui.Ok()
+	} else {
+		ui.Failed("Could not obtain access token for some of service keys")
+	}
+	ui.Say("")
+
+	// Display service keys
+	output := ui.NewOutput([]string{"service instance", "plan", "service key", "proxy host", "http port", "socks5 port", "rfc port", "token service URL", "token"})
+	for _, info := range infos {
+		serviceKey := info.ServiceKey
+		if info.Temporary {
+			serviceKey += " (temporary)"
+		}
+		token := terminal.SuccessColor(info.Token)
+		if info.Token != "ok" {
+			token = terminal.FailureColor(info.Token)
+		}
+		output.Add(info.ServiceInstance, info.Plan, serviceKey, valueOrDash(info.ProxyHost), valueOrDash(info.HTTPPort),
+			valueOrDash(info.SOCKS5Port), valueOrDash(info.RFCPort), valueOrDash(info.TokenServiceURL), token)
+	}
+	if err = output.Print(infos); err != nil {
+		ui.Failed("Could not print connectivity service keys: %s", err.Error())
+		return Failure
+	}
+
+	return status
+}
+
+// findConnectivityServiceInstances finds plans and service instances of
+// connectivity service in the space. If service instance name is provided,
+// only service instance with this name is returned
+func (c *ConnectivityCommand) findConnectivityServiceInstances(context Context, serviceInstanceName string) ([]models.CFServicePlan, []models.CFServiceInstance, error) {
+	log.Tracef("Getting list of services\n")
+	services, err := clients.GetServices(c.CliConnection)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get services: %s", err.Error())
+	}
+	servicePlans := make([]models.CFServicePlan, 0)
+	for _, service := range services {
+		if service.Name != "connectivity" {
+			continue
+		}
+		log.Tracef("Getting service plans for 'connectivity' service (GUID: %s)\n", service.GUID)
+		plans, err := clients.GetServicePlans(c.CliConnection, service.GUID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get service plans: %s", err.Error())
+		}
+		servicePlans = append(servicePlans, plans...)
+	}
+	if len(servicePlans) == 0 {
+		return nil, nil, errors.New("connectivity service is not in the list of available services." +
+			" Make sure your subaccount has entitlement to use it")
+	}
+
+	log.Tracef("Getting service instances of 'connectivity' service (%+v)\n", servicePlans)
+	serviceInstances, err := clients.GetServiceInstances(c.CliConnection, context.SpaceID, servicePlans)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get service instances of 'connectivity' service: %s", err.Error())
+	}
+	if serviceInstanceName == "" {
+		if len(serviceInstances) == 0 {
+			return nil, nil, errors.New("could not find service instance of 'connectivity' service in the space")
+		}
+		return servicePlans, serviceInstances, nil
+	}
+	for _, serviceInstance := range serviceInstances {
+		if serviceInstance.Name == serviceInstanceName {
+			return servicePlans, []models.CFServiceInstance{serviceInstance}, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("could not find service instance of 'connectivity' service with name '%s'", serviceInstanceName)
+}
+
+// newConnectivityInfo returns on-premise proxy settings of service key
+func newConnectivityInfo(serviceInstance models.CFServiceInstance, planName string, serviceKey models.CFServiceKey) ConnectivityInfo {
+	info := ConnectivityInfo{
+		ServiceInstance: serviceInstance.Name,
+		Plan:            planName,
+		ServiceKey:      serviceKey.Name,
+		Token:           "ok",
+	}
+	if connectivity := serviceKey.Credentials.Connectivity; connectivity != nil {
+		info.ProxyHost = connectivity.ProxyHost
+		info.HTTPPort = connectivity.ProxyHTTPPort
+		if info.HTTPPort == "" {
+			info.HTTPPort = connectivity.ProxyPort
+		}
+		info.SOCKS5Port = connectivity.SOCKS5ProxyPort
+		info.RFCPort = connectivity.ProxyRFCPort
+		info.TokenServiceURL = connectivity.TokenServiceURL
+	}
+	if info.TokenServiceURL == "" && serviceKey.Credentials.UAA != nil && serviceKey.Credentials.UAA.URL != "" {
+		info.TokenServiceURL = serviceKey.Credentials.UAA.URL + "/oauth/token"
+	}
+	return info
+}
+
+// valueOrDash returns value or '-' if value is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
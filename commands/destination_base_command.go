@@ -5,20 +5,42 @@ import (
 	clients "cf-cloud-connector/clients"
 	"cf-cloud-connector/clients/models"
 	"cf-cloud-connector/log"
+	"cf-cloud-connector/query"
+	"cf-cloud-connector/ui"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"syscall"
 
 	"github.com/cloudfoundry/cli/plugin"
 )
 
-// HTML5Command base struct for HTML5 repository operations
+// DestinationCommand base struct for destination service operations
 type DestinationCommand struct {
 	BaseCommand
+	// Create temporary destination service instance, if there is no
+	// service instance of 'lite' plan in the space
+	createTempInstance bool
+	// Temporary service instance and service key created by the command
+	tempContext DestinationContext
+	// Temporary service keys created by the command for existing service instances
+	tempKeys []models.CFServiceKey
+	// Guards temporary resources against concurrent clean-up on interrupt
+	tempContextMutex sync.Mutex
+	// Interrupt signals, which trigger clean-up of temporary resources
+	interrupts chan os.Signal
 }
+
+// tempInstanceNamePrefix prefix of names of temporary destination service instances
+const tempInstanceNamePrefix = "cloud-connector-tmp-"
+
 type DestinationContext struct {
 	// Pointer to destination service
 	DestinationServices []models.CFService
@@ -30,10 +52,36 @@ type DestinationContext struct {
 	DestinationServiceInstance *models.CFServiceInstance
 	// Pointer to destination service key created during context initialization
 	DestinationServiceInstanceKey *models.CFServiceKey
+	// Service keys of destination service instance used to access destination service
+	DestinationServiceInstanceKeys []models.CFServiceKey
 	// Access token of destination service key
 	DestinationServiceInstanceKeyToken string
 }
 
+// SubaccountSource source of destinations defined on subaccount level
+const SubaccountSource = "subaccount"
+
+// DestinationItem destination together with its source, which is either
+// 'subaccount' or name of destination service instance
+type DestinationItem struct {
+	Source      string                          `json:"source"`
+	Destination models.DestinationConfiguration `json:"destination"`
+}
+
+// Get returns source of destination, Cloud Connector location ID of OnPremise
+// destination or value of destination attribute or property, so that
+// destination can be filtered and sorted as query row. Fields are looked up
+// case-insensitively, the same way as fields of query.MapRow
+func (d DestinationItem) Get(field string) (string, bool) {
+	if strings.EqualFold(field, "source") {
+		return d.Source, true
+	}
+	if strings.EqualFold(field, "location") {
+		return d.Destination.CloudConnectorLocationID()
+	}
+	return query.MapRow(d.Destination.Map()).Get(field)
+}
+
 // Initialize initializes the command with the specified name and CLI connection
 func (c *DestinationCommand) Initialize(name string, cliConnection plugin.CliConnection) (err error) {
 	log.Tracef("Initializing command '%s'\n", name)
@@ -71,17 +119,143 @@ func (c *DestinationCommand) Dispose(name string) {
 
 }
 
-// GetDestinationContext get destination context
-func (c *DestinationCommand) GetDestinationContext(context Context) (models.DestinationAppResponse, error) {
+// GetDestinationContext get destination context. If destination service
+// instance name is provided, service key of this instance is used to access
+// destination service, otherwise the first instance of 'lite' plan found in
+// the space is used
+func (c *DestinationCommand) GetDestinationContext(context Context, destinationInstanceName string) (DestinationContext, error) {
+	log.Tracef("Getting destination context\n")
+
+	destinationContext, err := c.getDestinationServiceInstances(context)
+	if err != nil {
+		return destinationContext, err
+	}
+	destinationServiceInstances := destinationContext.DestinationServiceInstances
+
+	// Sort destination service instance so that the requested instance to be the first one in the list.
+	// If specific destinaton service instance name is required, but not found - return error
+	if destinationInstanceName != "" {
+		found := false
+		for idx, instance := range destinationServiceInstances {
+			if instance.Name == destinationInstanceName {
+				tmp := destinationServiceInstances[0]
+				destinationServiceInstances[0] = instance
+				destinationServiceInstances[idx] = tmp
+				found = true
+				break
+			}
+		}
+		if !found {
+			return destinationContext, c.newInstanceNotFoundError(context, destinationInstanceName, destinationServiceInstances)
+		}
+	}
+	destinationContext.DestinationServiceInstances = destinationServiceInstances
+	log.Tracef("Using service instance of 'destination' service 'lite' plan: %+v\n", destinationServiceInstances[0])
+
+	err = c.authorizeDestinationContext(&destinationContext)
+	return destinationContext, err
+}
+
+// newInstanceNotFoundError returns error for destination service instance name,
+// which does not match any of 'lite' plan service instances in the space
+func (c *DestinationCommand) newInstanceNotFoundError(context Context, destinationInstanceName string, destinationServiceInstances []models.CFServiceInstance) error {
+	names := make([]string, 0, len(destinationServiceInstances))
+	for _, instance := range destinationServiceInstances {
+		names = append(names, instance.Name)
+	}
+	sort.Strings(names)
+	if _, err := clients.GetServiceInstanceByName(c.CliConnection, context.SpaceID, destinationInstanceName); err == nil {
+		return fmt.Errorf("service instance '%s' is not an instance of 'destination' service 'lite' plan. "+
+			"Service instances of 'destination' service 'lite' plan in the space: %s", destinationInstanceName, strings.Join(names, ", "))
+	}
+	return fmt.Errorf("could not find service instance of 'destination' service 'lite' plan with name '%s'. "+
+		"Service instances of 'destination' service 'lite' plan in the space: %s", destinationInstanceName, strings.Join(names, ", "))
+}
+
+// GetDestinationContexts get destination contexts for all service instances
+// of destination service 'lite' plan in the space. In each of returned contexts
+// the first service instance in the list is the one used to access destination service
+func (c *DestinationCommand) GetDestinationContexts(context Context) ([]DestinationContext, error) {
+	log.Tracef("Getting destination contexts\n")
+
+	destinationContext, err := c.getDestinationServiceInstances(context)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationContexts := make([]DestinationContext, 0)
+	for idx := range destinationContext.DestinationServiceInstances {
+		instanceContext := destinationContext
+		instanceContext.DestinationServiceInstances = make([]models.CFServiceInstance, len(destinationContext.DestinationServiceInstances))
+		copy(instanceContext.DestinationServiceInstances, destinationContext.DestinationServiceInstances)
+		instanceContext.DestinationServiceInstances[0], instanceContext.DestinationServiceInstances[idx] =
+			instanceContext.DestinationServiceInstances[idx], instanceContext.DestinationServiceInstances[0]
+		log.Tracef("Using service instance of 'destination' service 'lite' plan: %+v\n", instanceContext.DestinationServiceInstances[0])
+		err = c.authorizeDestinationContext(&instanceContext)
+		if err != nil {
+			return nil, err
+		}
+		destinationContexts = append(destinationContexts, instanceContext)
+	}
+
+	return destinationContexts, nil
+}
+
+// authorizeDestinationContext get service key and access token of the first
+// service instance of destination context
+func (c *DestinationCommand) authorizeDestinationContext(destinationContext *DestinationContext) error {
+	destinationServiceInstances := destinationContext.DestinationServiceInstances
 
+	// Get service keys
+	destinationServiceInstanceKeys, err := clients.GetServiceKeys(c.CliConnection, destinationServiceInstances[0].GUID)
+	if err != nil {
+		return fmt.Errorf("could not get service keys of %s service instance: %s",
+			destinationServiceInstances[0].Name,
+			err.Error())
+	}
+
+	// Create temporary service key if needed
+	if len(destinationServiceInstanceKeys) == 0 {
+		destinationServiceInstanceKey, err := c.createTemporaryServiceKey(destinationServiceInstances[0])
+		if err != nil {
+			return fmt.Errorf("could not create service key of %s service instance: %s",
+				destinationServiceInstances[0].Name,
+				err.Error())
+		}
+		destinationServiceInstanceKeys = append(destinationServiceInstanceKeys, *destinationServiceInstanceKey)
+	}
+	log.Tracef("Found %d service keys for service %s, using service key with GUID=%s\n",
+		len(destinationServiceInstanceKeys),
+		destinationServiceInstances[0].Name,
+		destinationServiceInstanceKeys[len(destinationServiceInstanceKeys)-1].GUID)
+	destinationContext.DestinationServiceInstanceKeys = destinationServiceInstanceKeys
+
+	// Get destination service lite plan key access token
+	destinationServiceInstanceKey := destinationServiceInstanceKeys[len(destinationServiceInstanceKeys)-1]
+	log.Tracef("Getting token for service key %s\n", destinationServiceInstanceKey.Name)
+	destinationServiceInstanceKeyToken, err := clients.GetToken(destinationServiceInstanceKey.Credentials)
+	if err != nil {
+		return fmt.Errorf("could not obtain access token: %s", err.Error())
+	}
+	log.Tracef("Access token for service key %s: %s\n",
+		destinationServiceInstanceKey.Name,
+		log.Sensitive{Data: destinationServiceInstanceKeyToken})
+	destinationContext.DestinationServiceInstanceKeyToken = destinationServiceInstanceKeyToken
+
+	return nil
+}
+
+// getDestinationServiceInstances get destination context with destination
+// service, 'lite' plan and all service instances of 'lite' plan in the space
+func (c *DestinationCommand) getDestinationServiceInstances(context Context) (DestinationContext, error) {
 	// Context to return
-	var destinationApps = models.DestinationAppResponse{}
+	destinationContext := DestinationContext{}
 
 	// Get all services
 	log.Tracef("Getting list of services\n")
 	services, err := clients.GetServices(c.CliConnection)
 	if err != nil {
-		return destinationApps, errors.New("Could not get services: " + err.Error())
+		return destinationContext, errors.New("Could not get services: " + err.Error())
 	}
 
 	// Find destination service
@@ -90,137 +264,301 @@ func (c *DestinationCommand) GetDestinationContext(context Context) (models.Dest
 	for _, service := range services {
 		if service.Name == "destination" {
 			destinationServices = append(destinationServices, service)
-
 		}
 	}
 	if destinationServices == nil {
-		return destinationApps, fmt.Errorf("destination service is not in the list of available services." +
+		return destinationContext, fmt.Errorf("destination service is not in the list of available services." +
 			" Make sure your subaccount has entitlement to use it")
 	}
+	destinationContext.DestinationServices = destinationServices
 
+	// Find 'lite' plans of destination services
+	var liteServicePlans []models.CFServicePlan
 	for _, destinationService := range destinationServices {
 		log.Tracef("Getting service plans for 'destination' service (GUID: %s)\n", destinationService.GUID)
-		var liteServicePlan *models.CFServicePlan
 		destinationServicePlans, err := clients.GetServicePlans(c.CliConnection, destinationService.GUID)
 		if err != nil {
-			return destinationApps, fmt.Errorf("could not get service plans: %s", err.Error())
+			return destinationContext, fmt.Errorf("could not get service plans: %s", err.Error())
 		}
 		for _, servicePlan := range destinationServicePlans {
 			if servicePlan.Name == "lite" {
-				liteServicePlan = &servicePlan
+				liteServicePlans = append(liteServicePlans, servicePlan)
 				break
 			}
 		}
-		if liteServicePlan == nil {
-			return destinationApps, fmt.Errorf("destination service does not have a 'lite' plan")
-		}
-		var destinationContext = DestinationContext{}
-		destinationContext.DestinationServices = destinationServices
-		destinationContext.DestinationServicePlan = liteServicePlan
+	}
+	if liteServicePlans == nil {
+		return destinationContext, fmt.Errorf("destination service does not have a 'lite' plan")
+	}
+	destinationContext.DestinationServicePlan = &liteServicePlans[0]
 
-		log.Tracef("Getting service instances of 'destination' service 'lite' plan (%+v)\n", liteServicePlan)
-		var destinationServiceInstances []models.CFServiceInstance
-		destinationServiceInstances, err = clients.GetServiceInstances(c.CliConnection, context.SpaceID, []models.CFServicePlan{*liteServicePlan})
+	// Get list of service instances of 'lite' plan
+	log.Tracef("Getting service instances of 'destination' service 'lite' plan (%+v)\n", liteServicePlans)
+	destinationServiceInstances, err := clients.GetServiceInstances(c.CliConnection, context.SpaceID, liteServicePlans)
+	if err != nil {
+		return destinationContext, fmt.Errorf("could not get service instances for 'lite' plan: %s", err.Error())
+	}
+	if len(destinationServiceInstances) == 0 {
+		if !c.createTempInstance {
+			return destinationContext, fmt.Errorf("could not find service instance of 'destination' service 'lite' plan in the space." +
+				" Create one or use --create-temp-instance flag to create temporary service instance")
+		}
+		tempInstance, err := c.createTemporaryInstance(context, *destinationContext.DestinationServicePlan)
 		if err != nil {
-			return destinationApps, fmt.Errorf("could not get service instances for 'lite' plan: %s", err.Error())
+			return destinationContext, err
 		}
-		destinationContext.DestinationServiceInstances = destinationServiceInstances
-		for _, instance := range destinationServiceInstances {
-			// get service keys
-			destinationServiceInstanceKeys, err := clients.GetServiceKeys(c.CliConnection, instance.GUID)
-			if err != nil {
-				return destinationApps, fmt.Errorf("could not get service keys of %s service instance: %s",
-					instance.Name,
-					err.Error())
-			}
-			if len(destinationServiceInstanceKeys) == 0 {
-				log.Tracef("Creating service key for %s service instance\n", instance.Name)
-				destinationServiceInstanceKey, err := clients.CreateServiceKey(c.CliConnection, instance.GUID, nil)
-				if err != nil {
-					return destinationApps, fmt.Errorf("could not create service key of %s service instance: %s",
-						instance.Name,
-						err.Error())
-				}
-				destinationServiceInstanceKeys = append(destinationServiceInstanceKeys, *destinationServiceInstanceKey)
-			}
-			if len(destinationServiceInstanceKeys) > 0 {
-				log.Tracef("Found %d service keys for service %s, using service key with GUID=%s\n",
-					len(destinationServiceInstanceKeys),
-					instance.Name,
-					destinationServiceInstanceKeys[len(destinationServiceInstanceKeys)-1].GUID)
-				destinationServiceInstanceKeyToken, err := clients.GetToken(destinationServiceInstanceKeys[len(destinationServiceInstanceKeys)-1].Credentials)
-				if err != nil {
-					return destinationApps, fmt.Errorf("could not obtain access token: %s", err.Error())
-				}
-				//destinationContext.DestinationServiceInstanceKey = destinationServiceInstanceKeys[len(destinationServiceInstanceKeys)-1]
-				destinationApps, err = clients.ListDestinationDetails(*destinationServiceInstanceKeys[len(destinationServiceInstanceKeys)-1].Credentials.URI,
-					destinationServiceInstanceKeyToken, instance.GUID)
-				break
-			}
+		destinationServiceInstances = append(destinationServiceInstances, *tempInstance)
+	}
+	destinationContext.DestinationServiceInstances = destinationServiceInstances
 
-		}
+	return destinationContext, nil
+}
+
+// GetServiceInstance destination service instance used to access destination service
+func (ctx *DestinationContext) GetServiceInstance() models.CFServiceInstance {
+	return ctx.DestinationServiceInstances[0]
+}
 
+// GetServiceURL base URL of destination service REST API
+func (ctx *DestinationContext) GetServiceURL() string {
+	if len(ctx.DestinationServiceInstanceKeys) == 0 {
+		return ""
 	}
-	return destinationApps, nil
-	//log.Tracef("Destination services found: %+v\n", destinationContext.DestinationServices.)
-	//destinationContext.DestinationService = destinationService
+	uri := ctx.DestinationServiceInstanceKeys[len(ctx.DestinationServiceInstanceKeys)-1].Credentials.URI
+	if uri == nil {
+		return ""
+	}
+	return *uri
+}
 
-	// Get list of service instances of 'lite' plan
+// GetAllDestinations get subaccount destinations and destinations of all
+// destination service instances in the space or, if destination service
+// instance name is provided, only of this service instance. Subaccount
+// destinations, which are returned via every service instance, are listed only once
+func (c *DestinationCommand) GetAllDestinations(context Context, destinationInstanceName string) ([]DestinationItem, error) {
+	var destinationContexts []DestinationContext
+	if destinationInstanceName != "" {
+		destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+		if err != nil {
+			return nil, err
+		}
+		destinationContexts = []DestinationContext{destinationContext}
+	} else {
+		var err error
+		destinationContexts, err = c.GetDestinationContexts(context)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	// Sort destination service instance so that the requested instance to be the first one in the list.
-	// If specific destinaton service instance name is required, but not found - return error
-	/*if destinationInstanceName != "" {
-		found := false
-		for idx, instance := range destinationServiceInstances {
-			if instance.Name == destinationInstanceName {
-				tmp := destinationServiceInstances[0]
-				destinationServiceInstances[0] = instance
-				destinationServiceInstances[idx] = tmp
-				found = true
-				break
-			}
+	destinations := make([]DestinationItem, 0)
+	subaccountDestinationNames := make(map[string]bool)
+	for _, destinationContext := range destinationContexts {
+		instanceName := destinationContext.GetServiceInstance().Name
+
+		// Get subaccount destinations
+		subaccountDestinations, err := listDestinations(destinationContext, false)
+		if err != nil {
+			return nil, fmt.Errorf("could not get list of subaccount destinations via service instance %s: %s", instanceName, err.Error())
 		}
-		if !found {
-			return destinationContext, fmt.Errorf("Could not find service instance of 'destination' service 'lite' plan with name '%s'", destinationInstanceName)
+		for _, destination := range subaccountDestinations {
+			if subaccountDestinationNames[destination.Name] {
+				continue
+			}
+			subaccountDestinationNames[destination.Name] = true
+			destinations = append(destinations, DestinationItem{Source: SubaccountSource, Destination: destination})
 		}
-	}*/
 
-	// Create instance of 'lite' plan if needed
-	/*if len(destinationServiceInstances) == 0 {
-		log.Tracef("Creating service instance of 'destination' service 'lite' plan\n")
-		destinationServiceInstance, err := clients.CreateServiceInstance(c.CliConnection, context.SpaceID, *liteServicePlan, nil, "")
+		// Get service instance destinations
+		instanceDestinations, err := listDestinations(destinationContext, true)
 		if err != nil {
-			return destinationContext, fmt.Errorf("Could not create service instance of 'destination' service 'lite' plan: %s", err.Error())
+			return nil, fmt.Errorf("could not get list of destinations of service instance %s: %s", instanceName, err.Error())
 		}
-		destinationServiceInstances = append(destinationServiceInstances, *destinationServiceInstance)
-		destinationContext.DestinationServiceInstance = destinationServiceInstance
-	} else {
-		log.Tracef("Using service instance of 'destination' service 'lite' plan: %+v\n", destinationServiceInstances[0])
-	}*/
+		for _, destination := range instanceDestinations {
+			destinations = append(destinations, DestinationItem{Source: instanceName, Destination: destination})
+		}
+	}
 
-	// TODO: chech if there is an existing service key and use it, if found
+	return destinations, nil
+}
+
+// listDestinations lists destinations on subaccount level or, if instanceLevel
+// is set, on level of destination service instance of the context
+func listDestinations(destinationContext DestinationContext, instanceLevel bool) (models.DestinationListDestinationsResponse, error) {
+	if instanceLevel {
+		log.Tracef("Getting list of service instance destinations\n")
+		return clients.ListServiceInstanceDestinations(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken)
+	}
+	log.Tracef("Getting list of subaccount destinations\n")
+	return clients.ListSubaccountDestinations(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken)
+}
+
+// createDestination creates destination on subaccount level or, if instanceLevel
+// is set, on level of destination service instance of the context
+func createDestination(destinationContext DestinationContext, instanceLevel bool, destination models.DestinationConfiguration) error {
+	if instanceLevel {
+		log.Tracef("Creating service instance destination %s\n", destination.Name)
+		return clients.CreateServiceInstanceDestination(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, destination)
+	}
+	log.Tracef("Creating subaccount destination %s\n", destination.Name)
+	return clients.CreateSubaccountDestination(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, destination)
+}
+
+// updateDestination updates destination on subaccount level or, if instanceLevel
+// is set, on level of destination service instance of the context
+func updateDestination(destinationContext DestinationContext, instanceLevel bool, destination models.DestinationConfiguration) error {
+	if instanceLevel {
+		log.Tracef("Updating service instance destination %s\n", destination.Name)
+		return clients.UpdateServiceInstanceDestination(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, destination)
+	}
+	log.Tracef("Updating subaccount destination %s\n", destination.Name)
+	return clients.UpdateSubaccountDestination(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, destination)
+}
+
+// deleteDestination deletes destination on subaccount level or, if instanceLevel
+// is set, on level of destination service instance of the context
+func deleteDestination(destinationContext DestinationContext, instanceLevel bool, destinationName string) error {
+	if instanceLevel {
+		log.Tracef("Deleting service instance destination %s\n", destinationName)
+		return clients.DeleteServiceInstanceDestination(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, destinationName)
+	}
+	log.Tracef("Deleting subaccount destination %s\n", destinationName)
+	return clients.DeleteSubaccountDestination(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, destinationName)
+}
+
+// listCertificates lists certificates on subaccount level or, if instanceLevel
+// is set, on level of destination service instance of the context
+func listCertificates(destinationContext DestinationContext, instanceLevel bool) ([]models.DestinationCertificate, error) {
+	if instanceLevel {
+		log.Tracef("Getting list of service instance certificates\n")
+		return clients.ListServiceInstanceCertificates(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken)
+	}
+	log.Tracef("Getting list of subaccount certificates\n")
+	return clients.ListSubaccountCertificates(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken)
+}
+
+// getCertificate gets certificate with content on subaccount level or, if
+// instanceLevel is set, on level of destination service instance of the context
+func getCertificate(destinationContext DestinationContext, instanceLevel bool, certificateName string) (models.DestinationCertificate, error) {
+	if instanceLevel {
+		log.Tracef("Getting service instance certificate %s\n", certificateName)
+		return clients.GetServiceInstanceCertificate(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, certificateName)
+	}
+	log.Tracef("Getting subaccount certificate %s\n", certificateName)
+	return clients.GetSubaccountCertificate(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, certificateName)
+}
+
+// createCertificate uploads certificate on subaccount level or, if instanceLevel
+// is set, on level of destination service instance of the context
+func createCertificate(destinationContext DestinationContext, instanceLevel bool, certificate models.DestinationCertificate) error {
+	if instanceLevel {
+		log.Tracef("Uploading service instance certificate %s\n", certificate.Name)
+		return clients.CreateServiceInstanceCertificate(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, certificate)
+	}
+	log.Tracef("Uploading subaccount certificate %s\n", certificate.Name)
+	return clients.CreateSubaccountCertificate(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, certificate)
+}
+
+// deleteCertificate deletes certificate on subaccount level or, if instanceLevel
+// is set, on level of destination service instance of the context
+func deleteCertificate(destinationContext DestinationContext, instanceLevel bool, certificateName string) error {
+	if instanceLevel {
+		log.Tracef("Deleting service instance certificate %s\n", certificateName)
+		return clients.DeleteServiceInstanceCertificate(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, certificateName)
+	}
+	log.Tracef("Deleting subaccount certificate %s\n", certificateName)
+	return clients.DeleteSubaccountCertificate(destinationContext.GetServiceURL(), destinationContext.DestinationServiceInstanceKeyToken, certificateName)
+}
+
+// addTempInstanceFlag adds --create-temp-instance flag to flag set of command
+func (c *DestinationCommand) addTempInstanceFlag(flagSet *flag.FlagSet) {
+	flagSet.BoolVar(&c.createTempInstance, "create-temp-instance", false, "")
+}
+
+// createTemporaryInstance creates temporary service instance of 'lite' plan
+// together with its service key. Both are deleted by CleanTemporaryResources,
+// which is also called if command is interrupted
+func (c *DestinationCommand) createTemporaryInstance(context Context, servicePlan models.CFServicePlan) (*models.CFServiceInstance, error) {
+	c.tempContextMutex.Lock()
+	defer c.tempContextMutex.Unlock()
+	c.handleInterrupts()
+
+	// Create service instance
+	ui.Say("Creating temporary service instance of 'destination' service 'lite' plan...")
+	serviceInstance, err := clients.CreateServiceInstance(c.CliConnection, context.SpaceID, servicePlan, nil, tempInstanceNamePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("could not create temporary service instance of 'destination' service 'lite' plan: %s", err.Error())
+	}
+	log.Tracef("Temporary service instance %s created\n", serviceInstance.Name)
+	c.tempContext.DestinationServiceInstance = serviceInstance
 
 	// Create service key
-	/*log.Tracef("Creating service key for 'destination' service 'lite' plan\n")
-	destinationServiceInstanceKey, err := clients.CreateServiceKey(c.CliConnection, destinationServiceInstances[0].GUID, nil)
+	serviceKey, err := clients.CreateServiceKey(c.CliConnection, serviceInstance.GUID, nil)
 	if err != nil {
-		return destinationContext, fmt.Errorf("Could not create service key of %s service instance: %s",
-			destinationServiceInstances[0].Name,
-			err.Error())
+		return nil, fmt.Errorf("could not create service key of temporary service instance %s: %s", serviceInstance.Name, err.Error())
 	}
-	destinationContext.DestinationServiceInstanceKey = destinationServiceInstanceKey
+	log.Tracef("Service key %s of temporary service instance %s created\n", serviceKey.Name, serviceInstance.Name)
+	c.tempContext.DestinationServiceInstanceKey = serviceKey
 
-	// Get destination service lite plan key access token
-	log.Tracef("Getting token for service key %s\n", destinationServiceInstanceKey.Name)
-	destinationServiceInstanceKeyToken, err := clients.GetToken(destinationServiceInstanceKey.Credentials)
+	return serviceInstance, nil
+}
+
+// createTemporaryServiceKey creates service key of service instance, which
+// is deleted by CleanTemporaryResources when command finishes
+func (c *DestinationCommand) createTemporaryServiceKey(serviceInstance models.CFServiceInstance) (*models.CFServiceKey, error) {
+	c.tempContextMutex.Lock()
+	defer c.tempContextMutex.Unlock()
+	c.handleInterrupts()
+
+	log.Tracef("Creating temporary service key for %s service instance\n", serviceInstance.Name)
+	serviceKey, err := clients.CreateServiceKey(c.CliConnection, serviceInstance.GUID, nil)
 	if err != nil {
-		return destinationContext, fmt.Errorf("Could not obtain access token: %s", err.Error())
+		return nil, err
 	}
-	log.Tracef("Access token for service key %s: %s\n",
-		destinationServiceInstanceKey.Name,
-		log.Sensitive{Data: destinationServiceInstanceKeyToken})
-	destinationContext.DestinationServiceInstanceKeyToken = destinationServiceInstanceKeyToken*/
+	c.tempKeys = append(c.tempKeys, *serviceKey)
+	return serviceKey, nil
+}
+
+// handleInterrupts deletes temporary resources and exits, when
+// command is interrupted with Ctrl-C or terminated
+func (c *DestinationCommand) handleInterrupts() {
+	if c.interrupts != nil {
+		return
+	}
+	c.interrupts = make(chan os.Signal, 1)
+	signal.Notify(c.interrupts, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c.interrupts
+		ui.Warn("Interrupted, deleting temporary service keys and service instances...")
+		c.CleanTemporaryResources()
+		os.Exit(130)
+	}()
+}
 
+// CleanTemporaryResources deletes temporary service keys and service instance,
+// if they were created by the command. Failure to delete them is reported
+// as warning, so that they can be deleted manually or with cleanup command
+func (c *DestinationCommand) CleanTemporaryResources() {
+	c.tempContextMutex.Lock()
+	defer c.tempContextMutex.Unlock()
+	for _, serviceKey := range c.tempKeys {
+		log.Tracef("Deleting temporary service key %s\n", serviceKey.Name)
+		if err := clients.DeleteServiceKey(c.CliConnection, serviceKey.GUID, maxRetryCount); err != nil {
+			ui.Warn("Could not delete temporary service key %s: %s. Please delete it with [cf cloud-connector-cleanup]",
+				serviceKey.Name, err.Error())
+		}
+	}
+	c.tempKeys = nil
+	if c.tempContext.DestinationServiceInstance == nil {
+		return
+	}
+	instanceName := c.tempContext.DestinationServiceInstance.Name
+	log.Tracef("Deleting temporary service instance %s\n", instanceName)
+	if err := c.CleanDestinationContext(c.tempContext); err != nil {
+		ui.Warn("Could not delete temporary service instance %s: %s. Please delete it with [cf delete-service %s -f]",
+			instanceName, err.Error(), instanceName)
+	}
+	c.tempContext = DestinationContext{}
 }
 
 // CleanDestinationContext clean destination context
@@ -289,42 +627,6 @@ func (c *DestinationCommand) GetHTML5Context(context Context) (HTML5Context, err
 		}
 	}
 
-	//hhhahhahaha
-	var conService *models.CFService
-	for _, service := range services {
-		if service.Name == "destination" {
-			conService = &service
-		}
-	}
-	servicePlans_con, err := clients.GetServicePlans(c.CliConnection, conService.GUID)
-	_ = servicePlans_con
-	_ = err
-	var appa, err2 = clients.GetServiceInstances(c.CliConnection, context.SpaceID, servicePlans_con)
-	//log.Tracef(err.Error())
-	_ = appa
-	_ = err2
-
-	var destService *models.CFService
-	for _, service := range services {
-		if service.Name == "connectivity" {
-			destService = &service
-			break
-		}
-	}
-	_ = destService
-
-	destService_con, err := clients.GetServicePlans(c.CliConnection, destService.GUID)
-	_ = destService_con
-
-	var appa2, err3 = clients.GetServiceInstances(c.CliConnection, context.SpaceID, destService_con)
-	//log.Tracef(err.Error())
-	_ = appa2
-	_ = err3
-
-	if err != nil {
-		return html5Context, errors.New("Could not get service instances for app-runtime plan: " + err.Error())
-	}
-
 	if html5AppsRepoService == nil {
 		return html5Context, errors.New(serviceName + " service is not in the list of available services")
 	}
@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"cf-cloud-connector/validation"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// DestinationCreateCommand creates subaccount or service instance
+// level destination from command line flags
+type DestinationCreateCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *DestinationCreateCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-create",
+		HelpText: "Create destination on subaccount or destination service instance level",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-create DESTINATION_NAME -u URL [-t TYPE] [-pt PROXY_TYPE] [-auth AUTHENTICATION] [-desc DESCRIPTION] [-p KEY=VALUE]... [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"DESTINATION_NAME":                  "Name of destination to create",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-url, -u":                          "URL of destination",
+				"-type, -t":                         "Type of destination. Default value is 'HTTP'",
+				"-proxy-type, -pt":                  "Proxy type of destination (Internet, OnPremise, PrivateLink). Default value is 'Internet'",
+				"-authentication, -auth":            "Authentication type of destination. Default value is 'NoAuthentication'",
+				"-description, -desc":               "Description of destination",
+				"-property, -p":                     "Additional destination property in KEY=VALUE format. May be specified multiple times",
+				"-destination-instance, -di":        "Create destination on level of destination service instance with specified name instead of subaccount level",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of created destination: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *DestinationCreateCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var destination models.DestinationConfiguration
+	var properties stringSlice
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&destination.URL, "url", "", "")
+	flagSet.StringVar(&destination.URL, "u", "", "")
+	flagSet.StringVar(&destination.Type, "type", "HTTP", "")
+	flagSet.StringVar(&destination.Type, "t", "HTTP", "")
+	flagSet.StringVar(&destination.ProxyType, "proxy-type", "Internet", "")
+	flagSet.StringVar(&destination.ProxyType, "pt", "Internet", "")
+	flagSet.StringVar(&destination.Authentication, "authentication", "NoAuthentication", "")
+	flagSet.StringVar(&destination.Authentication, "auth", "NoAuthentication", "")
+	flagSet.StringVar(&destination.Description, "description", "", "")
+	flagSet.StringVar(&destination.Description, "desc", "", "")
+	flagSet.Var(&properties, "property", "")
+	flagSet.Var(&properties, "p", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) == 0 {
+		ui.Failed("Destination name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if len(positional) > 1 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	destination.Name = positional[0]
+	if destination.URL == "" {
+		ui.Failed("Destination URL is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	for _, property := range properties {
+		keyValue := strings.SplitN(property, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			ui.Failed("Property %q is not in KEY=VALUE format", property)
+			return Failure
+		}
+		destination.SetProperty(keyValue[0], keyValue[1])
+	}
+
+	issues := validation.Validate(destination)
+	if validation.HasErrors(issues) {
+		ui.Failed("Invalid destination %s: %s", destination.Name, joinIssues(validation.Errors(issues)))
+		return Failure
+	}
+	for _, warning := range validation.Warnings(issues) {
+		ui.Warn("Warning: %s", warning.Error())
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.CreateDestination(destination, destinationInstanceName)
+}
+
+// CreateDestination creates destination on subaccount level or, if destination
+// service instance name is provided, on level of this service instance
+func (c *DestinationCreateCommand) CreateDestination(destination models.DestinationConfiguration, destinationInstanceName string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		levelMessage = " in destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	ui.Say("Creating destination %s%s in org %s / space %s as %s...",
+		terminal.EntityNameColor(destination.Name),
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Create destination
+	err = createDestination(destinationContext, destinationInstanceName != "", destination)
+	if err != nil {
+		ui.Failed("Could not create destination %s: %s", destination.Name, err.Error())
+		return Failure
+	}
+
+	ui.Ok()
+
+	// Print created destination, secrets are never printed
+	if ui.IsMachineOutput() {
+		output := ui.NewOutput([]string{"name", "type", "proxy type", "authentication", "URL"})
+		output.Add(destination.Name, destination.Type, destination.ProxyType, destination.Authentication, destination.URL)
+		if err = output.Print(destination.Redacted()); err != nil {
+			ui.Failed("Could not print destination: %s", err.Error())
+			return Failure
+		}
+	}
+
+	return Success
+}
@@ -0,0 +1,169 @@
+package commands
+
+import (
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"path"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// DestinationDeleteCommand deletes subaccount or service instance
+// level destinations by name or glob pattern
+type DestinationDeleteCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *DestinationDeleteCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-delete",
+		HelpText: "Delete destinations on subaccount or destination service instance level",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-delete DESTINATION_NAME|PATTERN [DESTINATION_NAME|PATTERN...] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [-f] [--output FORMAT]",
+			Options: map[string]string{
+				"DESTINATION_NAME":                  "Name of destination to delete",
+				"PATTERN":                           "Glob pattern matching names of destinations to delete, e.g. 'A4H*'",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-destination-instance, -di":        "Delete destinations on level of destination service instance with specified name instead of subaccount level",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-force, -f":                        "Force deletion without confirmation",
+				"-output, -o":                       "Output format of result: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *DestinationDeleteCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var destinationInstanceName string
+	var force bool
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	flagSet.BoolVar(&force, "force", false, "")
+	flagSet.BoolVar(&force, "f", false, "")
+	patterns, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(patterns) == 0 {
+		ui.Failed("Destination name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			ui.Failed("Invalid destination name pattern %q: %s", pattern, err.Error())
+			return Failure
+		}
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.DeleteDestinations(patterns, destinationInstanceName, force)
+}
+
+// DeleteDestinations deletes destinations matching names or glob patterns
+// on subaccount level or, if destination service instance name is provided,
+// on level of this service instance
+func (c *DestinationDeleteCommand) DeleteDestinations(patterns []string, destinationInstanceName string, force bool) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	level := "subaccount"
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		level = destinationInstanceName
+		levelMessage = " in destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	ui.Say("Deleting destinations%s in org %s / space %s as %s...",
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get list of existing destinations
+	destinations, err := listDestinations(destinationContext, destinationInstanceName != "")
+	if err != nil {
+		ui.Failed("Could not get list of destinations: %s", err.Error())
+		return Failure
+	}
+
+	// Find destinations matching names or patterns
+	names := make([]string, 0)
+	notFound := make([]string, 0)
+	for _, pattern := range patterns {
+		found := false
+		for _, destination := range destinations {
+			if matched, _ := path.Match(pattern, destination.Name); matched {
+				found = true
+				if indexOfString(names, destination.Name) < 0 {
+					names = append(names, destination.Name)
+				}
+			}
+		}
+		if !found {
+			notFound = append(notFound, pattern)
+		}
+	}
+	if len(names) == 0 {
+		ui.Failed("Could not find destinations matching %s", strings.Join(patterns, ", "))
+		return Failure
+	}
+
+	// Ask for confirmation
+	if !force && !ui.Confirm("Really delete destinations %s?", terminal.EntityNameColor(strings.Join(names, ", "))) {
+		ui.Warn("Delete cancelled")
+		return Success
+	}
+
+	// Delete destinations
+	status := Success
+	output := ui.NewOutput([]string{"name", "level", "result"})
+	for _, name := range names {
+		err = deleteDestination(destinationContext, destinationInstanceName != "", name)
+		if err != nil {
+			status = Failure
+			output.Add(name, level, terminal.FailureColor("failed: "+err.Error()))
+		} else {
+			output.Add(name, level, terminal.SuccessColor("deleted"))
+		}
+	}
+	for _, pattern := range notFound {
+		output.Add(pattern, level, terminal.WarningColor("not found"))
+	}
+
+	if status == Success {
+		ui.Ok()
+	} else {
+		ui.Failed("Could not delete some of destinations")
+	}
+	ui.Say("")
+	if err = output.Print(nil); err != nil {
+		ui.Failed("Could not print result: %s", err.Error())
+		return Failure
+	}
+
+	return status
+}
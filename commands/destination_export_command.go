@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// DestinationExportCommand exports destinations to JSON file
+type DestinationExportCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *DestinationExportCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-export",
+		HelpText: "Export subaccount destinations and, optionally, destination service instance destinations to JSON file",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-export -f FILE [-i] [--include-secrets] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"FILE":                              "Path to JSON file, where destinations will be written",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-file, -f":                         "JSON file to write destinations to",
+				"-include-instance, -i":             "Export also destinations defined on level of destination service instance",
+				"-include-secrets":                  "Export passwords, client secrets and tokens in clear text instead of placeholders. Destinations with placeholders are rejected by import until real values are filled in",
+				"-destination-instance, -di":        "Use destination service instance with specified name",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of summary: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *DestinationExportCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var fileName string
+	var includeInstance bool
+	var includeSecrets bool
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&fileName, "file", "", "")
+	flagSet.StringVar(&fileName, "f", "", "")
+	flagSet.BoolVar(&includeInstance, "include-instance", false, "")
+	flagSet.BoolVar(&includeInstance, "i", false, "")
+	flagSet.BoolVar(&includeSecrets, "include-secrets", false, "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if fileName == "" {
+		ui.Failed("File to export destinations to is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.ExportDestinations(fileName, includeInstance, includeSecrets, destinationInstanceName)
+}
+
+// ExportDestinations writes destinations to file, replacing secrets with
+// placeholders, unless includeSecrets is set
+func (c *DestinationExportCommand) ExportDestinations(fileName string, includeInstance bool, includeSecrets bool, destinationInstanceName string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Exporting destinations to %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(fileName),
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get subaccount destinations
+	destinations, err := listDestinations(destinationContext, false)
+	if err != nil {
+		ui.Failed("Could not get list of subaccount destinations: %s", err.Error())
+		return Failure
+	}
+
+	// Get service instance destinations
+	if includeInstance {
+		instanceDestinations, err := listDestinations(destinationContext, true)
+		if err != nil {
+			ui.Failed("Could not get list of destinations of service instance %s: %s",
+				destinationContext.GetServiceInstance().Name, err.Error())
+			return Failure
+		}
+		destinations = append(destinations, instanceDestinations...)
+	}
+
+	// Replace secrets with placeholders
+	if !includeSecrets {
+		redactedDestinations := make([]models.DestinationConfiguration, 0, len(destinations))
+		for _, destination := range destinations {
+			redactedDestinations = append(redactedDestinations, destination.Redacted())
+		}
+		destinations = redactedDestinations
+	}
+
+	// Write file
+	data, err := json.MarshalIndent(destinations, "", "    ")
+	if err != nil {
+		ui.Failed("Could not marshal destinations: %s", err.Error())
+		return Failure
+	}
+	var perm os.FileMode = 0644
+	if includeSecrets {
+		perm = 0600
+	}
+	err = os.WriteFile(fileName, data, perm)
+	if err != nil {
+		ui.Failed("Could not write file %s: %s", fileName, err.Error())
+		return Failure
+	}
+
+	ui.Ok()
+	ui.Say("")
+	ui.Say("%d destinations exported", len(destinations))
+	if !includeSecrets {
+		ui.Say("Secrets are replaced with %s placeholder. Replace placeholders with real values before importing the file, or use --include-secrets flag to export secrets in clear text", models.RedactedValue)
+	}
+
+	// Print summary in machine-readable output formats
+	if ui.IsMachineOutput() {
+		output := ui.NewOutput([]string{"file", "exported", "secrets"})
+		output.Add(fileName, strconv.Itoa(len(destinations)), strconv.FormatBool(includeSecrets))
+		if err = output.Print(nil); err != nil {
+			ui.Failed("Could not print summary: %s", err.Error())
+			return Failure
+		}
+	}
+
+	return Success
+}
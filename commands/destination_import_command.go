@@ -0,0 +1,270 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"cf-cloud-connector/validation"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+const (
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictFail      = "fail"
+)
+
+const (
+	importActionCreate  = "create"
+	importActionUpdate  = "update"
+	importActionSkip    = "skip"
+	importActionInvalid = "invalid"
+)
+
+// DestinationImportCommand imports destinations from JSON file
+type DestinationImportCommand struct {
+	DestinationCommand
+}
+
+// importPlanItem planned import action for single destination
+type importPlanItem struct {
+	Destination models.DestinationConfiguration
+	Action      string
+	Details     string
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *DestinationImportCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-import",
+		HelpText: "Import destinations from JSON file on subaccount or destination service instance level",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-import -f FILE [--on-conflict skip|overwrite|fail] [--dry-run] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"FILE":                              "Path to JSON file with array of destination configurations",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-file, -f":                         "JSON file with destinations to import",
+				"-on-conflict":                      "Action for destinations that already exist: 'skip', 'overwrite' or 'fail'. Default value is 'skip'",
+				"-dry-run":                          "Only show the plan of actions, without applying it",
+				"-destination-instance, -di":        "Import destinations on level of destination service instance with specified name instead of subaccount level",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of plan and result: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *DestinationImportCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var fileName string
+	var onConflict string
+	var dryRun bool
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&fileName, "file", "", "")
+	flagSet.StringVar(&fileName, "f", "", "")
+	flagSet.StringVar(&onConflict, "on-conflict", conflictSkip, "")
+	flagSet.BoolVar(&dryRun, "dry-run", false, "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if fileName == "" {
+		ui.Failed("File with destinations is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if onConflict != conflictSkip && onConflict != conflictOverwrite && onConflict != conflictFail {
+		ui.Failed("Invalid value of --on-conflict flag: %q. Use 'skip', 'overwrite' or 'fail'", onConflict)
+		return Failure
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.ImportDestinations(fileName, onConflict, dryRun, destinationInstanceName)
+}
+
+// ImportDestinations imports destinations from file on subaccount level or,
+// if destination service instance name is provided, on level of this service instance
+func (c *DestinationImportCommand) ImportDestinations(fileName string, onConflict string, dryRun bool, destinationInstanceName string) ExecutionStatus {
+	// Read destinations from file
+	log.Tracef("Reading destinations from file %s\n", fileName)
+	destinations, err := readDestinationsFile(fileName)
+	if err != nil {
+		ui.Failed("Could not read destinations from file %s: %s", fileName, err.Error())
+		return Failure
+	}
+
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		levelMessage = " in destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	ui.Say("Importing destinations from %s%s in org %s / space %s as %s...",
+		terminal.EntityNameColor(fileName),
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get list of existing destinations
+	existingDestinations, err := listDestinations(destinationContext, destinationInstanceName != "")
+	if err != nil {
+		ui.Failed("Could not get list of destinations: %s", err.Error())
+		return Failure
+	}
+
+	// Build plan
+	plan, conflicts := planImport(destinations, existingDestinations, onConflict)
+
+	ui.Ok()
+	ui.Say("")
+	ui.Say("Plan:")
+
+	// In machine-readable output formats plan is printed only on dry run
+	if !ui.IsMachineOutput() || dryRun {
+		output := ui.NewOutput([]string{"name", "action", "details"})
+		for _, item := range plan {
+			output.Add(item.Destination.Name, item.Action, item.Details)
+		}
+		if err = output.Print(nil); err != nil {
+			ui.Failed("Could not print plan: %s", err.Error())
+			return Failure
+		}
+	}
+
+	if onConflict == conflictFail && conflicts > 0 {
+		ui.Failed("%d destinations already exist. Nothing was imported", conflicts)
+		return Failure
+	}
+	if dryRun {
+		ui.Say("")
+		ui.Say("Dry run, no changes were applied")
+		return Success
+	}
+
+	// Apply plan
+	ui.Say("")
+	status := Success
+	succeeded := 0
+	failed := 0
+	output := ui.NewOutput([]string{"name", "action", "result"})
+	for _, item := range plan {
+		switch item.Action {
+		case importActionCreate:
+			err = createDestination(destinationContext, destinationInstanceName != "", item.Destination)
+		case importActionUpdate:
+			err = updateDestination(destinationContext, destinationInstanceName != "", item.Destination)
+		case importActionSkip:
+			output.Add(item.Destination.Name, item.Action, "skipped")
+			continue
+		default:
+			err = errors.New(item.Details)
+		}
+		if err != nil {
+			status = Failure
+			failed++
+			output.Add(item.Destination.Name, item.Action, terminal.FailureColor("failed: "+err.Error()))
+		} else {
+			succeeded++
+			output.Add(item.Destination.Name, item.Action, terminal.SuccessColor("succeeded"))
+		}
+	}
+	if err = output.Print(nil); err != nil {
+		ui.Failed("Could not print result: %s", err.Error())
+		return Failure
+	}
+	ui.Say("")
+	ui.Say("%d succeeded, %d failed", succeeded, failed)
+
+	return status
+}
+
+// readDestinationsFile reads array of destination configurations from JSON file
+func readDestinationsFile(fileName string) ([]models.DestinationConfiguration, error) {
+	var destinations []models.DestinationConfiguration
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &destinations)
+	if err != nil {
+		return nil, err
+	}
+	return destinations, nil
+}
+
+// planImport builds import plan for destinations and returns it together
+// with the number of destinations that already exist
+func planImport(destinations []models.DestinationConfiguration, existingDestinations []models.DestinationConfiguration, onConflict string) ([]importPlanItem, int) {
+	plan := make([]importPlanItem, 0)
+	names := make([]string, 0)
+	conflicts := 0
+	for _, destination := range destinations {
+		item := importPlanItem{Destination: destination, Action: importActionCreate}
+		if validationErrors := validation.Errors(validation.Validate(destination)); len(validationErrors) > 0 {
+			item.Action = importActionInvalid
+			item.Details = joinIssues(validationErrors)
+		} else if indexOfString(names, destination.Name) >= 0 {
+			item.Action = importActionInvalid
+			item.Details = "duplicate destination name in file"
+		} else {
+			for _, existingDestination := range existingDestinations {
+				if existingDestination.Name == destination.Name {
+					conflicts++
+					item.Details = "already exists"
+					if onConflict == conflictOverwrite {
+						item.Action = importActionUpdate
+					} else {
+						item.Action = importActionSkip
+					}
+					break
+				}
+			}
+		}
+		names = append(names, destination.Name)
+		plan = append(plan, item)
+	}
+	return plan, conflicts
+}
+
+// joinIssues joins validation issues into single message
+func joinIssues(issues []validation.Issue) string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.Error())
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"cf-cloud-connector/validation"
+	"os"
+	"strconv"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// DestinationLintCommand validates destinations of JSON file without
+// sending them to destination service
+type DestinationLintCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *DestinationLintCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-lint",
+		HelpText: "Validate destinations of JSON file and report problems with line numbers",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-lint -f FILE [--output FORMAT]",
+			Options: map[string]string{
+				"FILE":        "Path to JSON file with array of destination configurations",
+				"-file, -f":   "JSON file with destinations to validate",
+				"-output, -o": "Output format of problems: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *DestinationLintCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var fileName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	flagSet.StringVar(&fileName, "file", "", "")
+	flagSet.StringVar(&fileName, "f", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if fileName == "" {
+		ui.Failed("File with destinations is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	return c.LintDestinations(fileName)
+}
+
+// LintDestinations validates destinations of file and prints found problems
+func (c *DestinationLintCommand) LintDestinations(fileName string) ExecutionStatus {
+	ui.Say("Validating destinations of %s...", terminal.EntityNameColor(fileName))
+
+	// Read file
+	log.Tracef("Reading destinations from file %s\n", fileName)
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		ui.Failed("Could not read file %s: %s", fileName, err.Error())
+		return Failure
+	}
+
+	// Validate destinations
+	issues, destinations, err := validation.LintFile(data)
+	if err != nil {
+		ui.Failed("Could not parse file %s: %s", fileName, err.Error())
+		return Failure
+	}
+	errorsCount := len(validation.Errors(issues))
+	if errorsCount > 0 {
+		ui.Failed("%d errors found", errorsCount)
+	} else {
+		ui.Ok()
+	}
+	ui.Say("")
+
+	// Display problems
+	if len(issues) > 0 || ui.IsMachineOutput() {
+		output := ui.NewOutput([]string{"line", "destination", "severity", "field", "message"})
+		for _, issue := range issues {
+			severity := terminal.WarningColor(issue.Severity)
+			if issue.Severity == validation.SeverityError {
+				severity = terminal.FailureColor(issue.Severity)
+			}
+			output.Add(strconv.Itoa(issue.Line), issue.Destination, severity, issue.Field, issue.Message)
+		}
+		if err = output.Print(issues); err != nil {
+			ui.Failed("Could not print problems: %s", err.Error())
+			return Failure
+		}
+		ui.Say("")
+	}
+	ui.Say("%d destinations checked, %d errors, %d warnings",
+		len(destinations), errorsCount, len(validation.Warnings(issues)))
+
+	if errorsCount > 0 {
+		return Failure
+	}
+	return Success
+}
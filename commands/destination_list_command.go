@@ -1,60 +1,158 @@
 package commands
 
 import (
-	"cf-cloud-connector/clients/models"
 	"cf-cloud-connector/log"
+	"cf-cloud-connector/query"
 	"cf-cloud-connector/ui"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin"
 )
 
-// ListCommand prints the list of HTML5 applications
-// deployed using multiple instances of html5-apps-repo
-// service app-host plan
+// DestinationListCommand prints the list of subaccount destinations
+// and destinations of all destination service instances in the space
 type DestinationListCommand struct {
 	DestinationCommand
 }
 
+// CloudConnectorLocation OnPremise destinations served by Cloud Connector
+// with location ID. Empty location ID means default location
+type CloudConnectorLocation struct {
+	LocationID   string            `json:"locationId"`
+	Destinations []DestinationItem `json:"destinations"`
+	VirtualHosts []string          `json:"virtualHosts"`
+}
+
+// defaultLocationName display name of default Cloud Connector location
+const defaultLocationName = "(default)"
+
+// DestinationListFilter filter of destinations list
+type DestinationListFilter struct {
+	ProxyType      string
+	Authentication string
+	Type           string
+	// Filter expression applied to destination attributes and properties
+	Query *query.Filter
+}
+
 // GetPluginCommand returns the plugin command details
-func (c *DestinationCommand) GetPluginCommand() plugin.Command {
+func (c *DestinationListCommand) GetPluginCommand() plugin.Command {
 	return plugin.Command{
 		Name:     "cloud-connector-list",
-		HelpText: "Display list of HTML5 applications or file paths of specified application",
+		HelpText: "Display list of subaccount destinations and destinations of destination service instances",
 		UsageDetails: plugin.Usage{
-			Usage: "cf html5-list [APP_NAME] [APP_VERSION] [APP_HOST_ID|-n APP_HOST_NAME] [-d|-di DESTINATION_SERVICE_INSTANCE_NAME|-a CF_APP_NAME [-rt RUNTIME] [-u]]",
+			Usage: "cf cloud-connector-list [--on-premise|--proxy-type PROXY_TYPE] [--auth AUTHENTICATION] [--type TYPE] [--columns PROPERTY[,PROPERTY...]] [--filter EXPRESSION] [--sort PROPERTY[,-PROPERTY...]] [--group-by-location] [--include-secrets] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
 			Options: map[string]string{
-				"APP_NAME":                          "Application name, which file paths should be listed. If not provided, list of applications will be printed",
-				"APP_VERSION":                       "Application version, which file paths should be listed. If not provided, current active version will be used",
-				"APP_HOST_ID":                       "GUID of html5-apps-repo app-host service instance that contains application with specified name and version",
-				"APP_HOST_NAME":                     "Name of html5-apps-repo app-host service instance that contains application with specified name and version",
+				"PROXY_TYPE":                        "Proxy type of destination, e.g. Internet, OnPremise, PrivateLink",
+				"AUTHENTICATION":                    "Authentication type of destination, e.g. NoAuthentication, BasicAuthentication, OAuth2ClientCredentials",
+				"TYPE":                              "Type of destination, e.g. HTTP, RFC, MAIL, LDAP",
+				"PROPERTY":                          "Name of destination property, e.g. CloudConnectorLocationId, sap-client, WebIDEUsage",
 				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
-				"-destination, -d":                  "List HTML5 applications exposed via subaccount destinations with sap.cloud.service and html5-apps-repo.app_host_id properties",
-				"-destination-instance, -di":        "List HTML5 applications exposed via service instance destinations with sap.cloud.service and html5-apps-repo.app_host_id properties",
-				"-name, -n":                         "Use html5-apps-repo app-host service instance name instead of APP_HOST_ID",
-				"-app, -a":                          "Cloud Foundry application name, which is bound to services that expose UI via html5-apps-repo",
-				"-runtime, -rt":                     "Runtime service for which conventional URLs of applications will be shown. Default value is 'cpp'",
-				"-url, -u":                          "Show conventional URLs of applications, when accessed via Cloud Foundry application specified with --app flag or when --destination or --destination-instance flag is used",
+				"EXPRESSION":                        "Conditions PROPERTY=VALUE, PROPERTY!=VALUE, PROPERTY~REGEXP or PROPERTY!~REGEXP joined with 'and' or 'or', e.g. 'ProxyType=OnPremise and sap-client!=000 and Name~^A4H'",
+				"-on-premise":                       "List only destinations with OnPremise proxy type",
+				"-proxy-type, -pt":                  "List only destinations with specified proxy type",
+				"-authentication, -auth":            "List only destinations with specified authentication type",
+				"-type, -t":                         "List only destinations of specified type",
+				"-columns, -c":                      "Comma-separated list of additional destination properties to display",
+				"-filter":                           "List only destinations matching filter expression",
+				"-sort":                             "Comma-separated list of destination properties to sort by. Properties prefixed with '-' are sorted in descending order",
+				"-include-secrets":                  "Display passwords, client secrets and tokens in clear text instead of placeholders",
+				"-group-by-location, -gl":           "List only OnPremise destinations grouped by Cloud Connector location ID with summary of destinations and virtual hosts per location",
+				"-destination-instance, -di":        "List only subaccount destinations and destinations of destination service instance with specified name",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format: table, json, yaml or csv. Default value is 'table'",
 			},
 		},
 	}
 }
 
 // Execute executes plugin command
-func (c *DestinationCommand) Execute(args []string) ExecutionStatus {
+func (c *DestinationListCommand) Execute(args []string) ExecutionStatus {
 	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
 
-	// List apps in the space
-	if len(args) == 0 {
-		return c.ListApps(nil)
+	var filter DestinationListFilter
+	var onPremise bool
+	var columns string
+	var includeSecrets bool
+	var filterExpression string
+	var sortExpression string
+	var destinationInstanceName string
+	var groupByLocation bool
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.BoolVar(&onPremise, "on-premise", false, "")
+	flagSet.StringVar(&filter.ProxyType, "proxy-type", "", "")
+	flagSet.StringVar(&filter.ProxyType, "pt", "", "")
+	flagSet.StringVar(&filter.Authentication, "authentication", "", "")
+	flagSet.StringVar(&filter.Authentication, "auth", "", "")
+	flagSet.StringVar(&filter.Type, "type", "", "")
+	flagSet.StringVar(&filter.Type, "t", "", "")
+	flagSet.StringVar(&columns, "columns", "", "")
+	flagSet.StringVar(&columns, "c", "", "")
+	flagSet.StringVar(&filterExpression, "filter", "", "")
+	flagSet.StringVar(&sortExpression, "sort", "", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	flagSet.BoolVar(&groupByLocation, "group-by-location", false, "")
+	flagSet.BoolVar(&groupByLocation, "gl", false, "")
+	flagSet.BoolVar(&includeSecrets, "include-secrets", false, "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if onPremise {
+		if filter.ProxyType != "" && !strings.EqualFold(filter.ProxyType, "OnPremise") {
+			ui.Failed("Flags --on-premise and --proxy-type %s can not be used together", filter.ProxyType)
+			return Failure
+		}
+		filter.ProxyType = "OnPremise"
+	}
+	if groupByLocation {
+		if filter.ProxyType != "" && !strings.EqualFold(filter.ProxyType, "OnPremise") {
+			ui.Failed("Flags --group-by-location and --proxy-type %s can not be used together", filter.ProxyType)
+			return Failure
+		}
+		filter.ProxyType = "OnPremise"
+	}
+	filter.Query, err = query.ParseFilter(filterExpression)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
 	}
+	destinationSort, err := query.ParseSort(sortExpression)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+	additionalColumns := make([]string, 0)
+	for _, column := range strings.Split(columns, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			additionalColumns = append(additionalColumns, column)
+		}
+	}
+
+	defer c.CleanTemporaryResources()
 
-	ui.Failed("Too many arguments. See [cf html5-list --help] for more details")
-	return Failure
+	return c.ListDestinations(filter, destinationSort, additionalColumns, destinationInstanceName, groupByLocation, includeSecrets)
 }
 
-// ListApps get list of applications for given app-host-id or current space
-func (c *DestinationCommand) ListApps(appHostGUID *string) ExecutionStatus {
+// ListDestinations prints destinations matching filter, sorted by sort
+// expression, with default and additional columns. If destination service
+// instance name is provided, only destinations of this instance are listed.
+// If groupByLocation is set, destinations are grouped by Cloud Connector
+// location ID. Secrets are replaced by placeholders, unless includeSecrets
+// is set
+func (c *DestinationListCommand) ListDestinations(filter DestinationListFilter, destinationSort *query.Sort, additionalColumns []string, destinationInstanceName string, groupByLocation bool, includeSecrets bool) ExecutionStatus {
 	// Get context
 	log.Tracef("Getting context (org/space/username)\n")
 	context, err := c.GetContext()
@@ -63,44 +161,190 @@ func (c *DestinationCommand) ListApps(appHostGUID *string) ExecutionStatus {
 		return Failure
 	}
 
-	appHostMessage := ""
-	if appHostGUID != nil {
-		appHostMessage = " with app-host-id " + terminal.EntityNameColor(*appHostGUID)
-	}
-
-	ui.Say("Getting list of Destinations applications%s in org %s / space %s as %s...",
-		appHostMessage,
+	ui.Say("Getting list of destinations in org %s / space %s as %s...",
 		terminal.EntityNameColor(context.Org),
 		terminal.EntityNameColor(context.Space),
 		terminal.EntityNameColor(context.Username))
 
-	// Get HTML5 context
-	destinationContext, err := c.GetDestinationContext(context)
+	// Get destinations of all destination service instances
+	destinations, err := c.GetAllDestinations(context, destinationInstanceName)
 	if err != nil {
 		ui.Failed(err.Error())
 		return Failure
 	}
-	_ = destinationContext
-	// Find app-host service plan
-	log.Tracef("Looking for app-host service plan\n")
-	var appHostServicePlan *models.CFServicePlan
-	_ = appHostServicePlan
 
 	ui.Ok()
 	ui.Say("")
-	var onPremiseApps []models.DestinationApp
-	for _, service := range destinationContext {
-		if service.ProxyType == "OnPremise" {
-			onPremiseApps = append(onPremiseApps, service)
+
+	// Redact secrets before filtering and sorting, so that matching rows
+	// do not reveal secret values
+	if !includeSecrets {
+		destinations = redactDestinationItems(destinations)
+	}
+
+	// Sort destinations
+	sort.SliceStable(destinations, func(i, j int) bool {
+		return destinationSort.Less(destinations[i], destinations[j])
+	})
+
+	// Filter destinations
+	matchingDestinations := make([]DestinationItem, 0)
+	for _, destination := range destinations {
+		if filter.Matches(destination) {
+			matchingDestinations = append(matchingDestinations, destination)
 		}
 	}
 
-	// Display information about HTML5 applications
-	table := ui.Table([]string{"name", "description", "type", "URL"})
-	for _, service := range onPremiseApps {
-		table.Add(service.Name, service.Description, service.ProxyType, service.URL)
+	if groupByLocation {
+		err = printDestinationsByLocation(matchingDestinations, additionalColumns)
+	} else {
+		err = printDestinations(matchingDestinations, additionalColumns)
+	}
+	if err != nil {
+		ui.Failed("Could not print destinations: %s", err.Error())
+		return Failure
 	}
-	table.Print()
 
 	return Success
 }
+
+// printDestinations prints destinations with default and additional columns
+func printDestinations(destinations []DestinationItem, additionalColumns []string) error {
+	output := ui.NewOutput(newDestinationsHeaders(additionalColumns))
+	for _, destination := range destinations {
+		output.Add(destinationRow(destination, additionalColumns)...)
+	}
+	return output.Print(destinations)
+}
+
+// printDestinationsByLocation prints destinations grouped by Cloud Connector
+// location ID followed by summary of destinations and virtual hosts of each
+// location
+func printDestinationsByLocation(destinations []DestinationItem, additionalColumns []string) error {
+	locations := groupDestinationsByLocation(destinations)
+
+	// Print model in machine-readable output formats. CSV lists rows of
+	// destinations, which contain location column
+	if ui.IsMachineOutput() {
+		output := ui.NewOutput(newDestinationsHeaders(additionalColumns))
+		for _, location := range locations {
+			for _, destination := range location.Destinations {
+				output.Add(destinationRow(destination, additionalColumns)...)
+			}
+		}
+		return output.Print(locations)
+	}
+
+	// Print destinations of each location
+	for _, location := range locations {
+		ui.Say("Location %s:", terminal.EntityNameColor(locationName(location.LocationID)))
+		table := ui.Table(newDestinationsHeaders(additionalColumns))
+		for _, destination := range location.Destinations {
+			table.Add(destinationRow(destination, additionalColumns)...)
+		}
+		table.Print()
+		ui.Say("")
+	}
+
+	// Print summary
+	ui.Say("Summary:")
+	table := ui.Table([]string{"location", "destinations", "virtual hosts"})
+	for _, location := range locations {
+		table.Add(locationName(location.LocationID), strconv.Itoa(len(location.Destinations)), strings.Join(location.VirtualHosts, ", "))
+	}
+	table.Print()
+	return nil
+}
+
+// groupDestinationsByLocation groups OnPremise destinations by Cloud
+// Connector location ID. Locations are sorted by ID, default location first
+func groupDestinationsByLocation(destinations []DestinationItem) []CloudConnectorLocation {
+	locations := make([]CloudConnectorLocation, 0)
+	locationIndexes := make(map[string]int)
+	for _, destination := range destinations {
+		locationID, ok := destination.Destination.CloudConnectorLocationID()
+		if !ok {
+			continue
+		}
+		idx, found := locationIndexes[locationID]
+		if !found {
+			idx = len(locations)
+			locationIndexes[locationID] = idx
+			locations = append(locations, CloudConnectorLocation{
+				LocationID:   locationID,
+				Destinations: make([]DestinationItem, 0),
+				VirtualHosts: make([]string, 0),
+			})
+		}
+		locations[idx].Destinations = append(locations[idx].Destinations, destination)
+		virtualHost := destination.Destination.VirtualHost()
+		if virtualHost != "" && indexOfString(locations[idx].VirtualHosts, virtualHost) < 0 {
+			locations[idx].VirtualHosts = append(locations[idx].VirtualHosts, virtualHost)
+		}
+	}
+	sort.SliceStable(locations, func(i, j int) bool {
+		return locations[i].LocationID < locations[j].LocationID
+	})
+	for _, location := range locations {
+		sort.Strings(location.VirtualHosts)
+	}
+	return locations
+}
+
+// locationName returns display name of Cloud Connector location ID
+func locationName(locationID string) string {
+	if locationID == "" {
+		return defaultLocationName
+	}
+	return locationID
+}
+
+// newDestinationsHeaders returns headers of default and additional columns
+func newDestinationsHeaders(additionalColumns []string) []string {
+	headers := []string{"name", "description", "type", "proxy type", "location", "authentication", "URL", "source"}
+	return append(headers, additionalColumns...)
+}
+
+// destinationRow returns values of default and additional columns of destination
+func destinationRow(destination DestinationItem, additionalColumns []string) []string {
+	location := "-"
+	if locationID, ok := destination.Destination.CloudConnectorLocationID(); ok {
+		location = locationName(locationID)
+	}
+	row := []string{
+		destination.Destination.Name,
+		destination.Destination.Description,
+		destination.Destination.Type,
+		destination.Destination.ProxyType,
+		location,
+		destination.Destination.Authentication,
+		destination.Destination.URL,
+		destination.Source,
+	}
+	for _, column := range additionalColumns {
+		value, ok := destination.Destination.GetProperty(column)
+		if !ok {
+			value = "-"
+		}
+		row = append(row, value)
+	}
+	return row
+}
+
+// redactDestinationItems returns destinations with secrets replaced by placeholders
+func redactDestinationItems(destinations []DestinationItem) []DestinationItem {
+	redacted := make([]DestinationItem, 0, len(destinations))
+	for _, destination := range destinations {
+		redacted = append(redacted, DestinationItem{Source: destination.Source, Destination: destination.Destination.Redacted()})
+	}
+	return redacted
+}
+
+// Matches checks if destination matches filter. Empty filter values match
+// any destination
+func (f DestinationListFilter) Matches(destination DestinationItem) bool {
+	return (f.ProxyType == "" || strings.EqualFold(f.ProxyType, destination.Destination.ProxyType)) &&
+		(f.Authentication == "" || strings.EqualFold(f.Authentication, destination.Destination.Authentication)) &&
+		(f.Type == "" || strings.EqualFold(f.Type, destination.Destination.Type)) &&
+		f.Query.Matches(destination)
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"sort"
+	"strconv"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// DestinationResolveCommand looks up destination via destination service
+// find destination API and prints resolved authentication tokens and
+// certificates
+type DestinationResolveCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *DestinationResolveCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-resolve",
+		HelpText: "Look up destination and display authentication tokens and certificates resolved by destination service",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-resolve DESTINATION_NAME [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [-ut USER_TOKEN] [--show-secrets] [--output FORMAT]",
+			Options: map[string]string{
+				"DESTINATION_NAME":                  "Name of destination to look up",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"USER_TOKEN":                        "JWT of business user, required for destinations with user propagation, e.g. OAuth2SAMLBearerAssertion",
+				"-destination-instance, -di":        "Look up destination using destination service instance with specified name",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-user-token, -ut":                  "Send user token in X-user-token header",
+				"-show-secrets":                     "Display tokens, certificates and secret destination properties in clear text instead of placeholders",
+				"-output, -o":                       "Output format: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *DestinationResolveCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var destinationInstanceName string
+	var userToken string
+	var showSecrets bool
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	flagSet.StringVar(&userToken, "user-token", "", "")
+	flagSet.StringVar(&userToken, "ut", "", "")
+	flagSet.BoolVar(&showSecrets, "show-secrets", false, "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) == 0 {
+		ui.Failed("Destination name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if len(positional) > 1 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.ResolveDestination(positional[0], destinationInstanceName, userToken, showSecrets)
+}
+
+// ResolveDestination looks up destination and prints its configuration,
+// authentication tokens and certificates. Secrets are replaced with
+// placeholders, unless showSecrets is set
+func (c *DestinationResolveCommand) ResolveDestination(destinationName string, destinationInstanceName string, userToken string, showSecrets bool) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Resolving destination %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(destinationName),
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Find destination
+	log.Tracef("Finding destination %s, user token: %v\n", destinationName, log.Sensitive{Data: userToken})
+	destination, err := clients.FindDestination(destinationContext.GetServiceURL(),
+		destinationContext.DestinationServiceInstanceKeyToken, destinationName, userToken)
+	if err != nil {
+		ui.Failed("Could not resolve destination %s: %s", destinationName, err.Error())
+		return Failure
+	}
+	if !showSecrets {
+		destination = destination.Redacted()
+	}
+
+	// Destination service reports errors of authentication flow in tokens
+	status := Success
+	for _, authToken := range destination.AuthTokens {
+		if authToken.Error != "" {
+			status = Failure
+		}
+	}
+
+	ui.Ok()
+	ui.Say("")
+
+	// Print model in machine-readable output formats
+	if ui.IsMachineOutput() {
+		output := ui.NewOutput([]string{"type", "expires in", "error", "value"})
+		for _, authToken := range destination.AuthTokens {
+			output.Add(authToken.Type, authToken.ExpiresIn, authToken.Error, authToken.Value)
+		}
+		if err = output.Print(&destination); err != nil {
+			ui.Failed("Could not print destination: %s", err.Error())
+			return Failure
+		}
+		return status
+	}
+
+	// Display owner and configuration
+	owner := "subaccount " + destination.Owner.SubaccountID
+	if destination.Owner.InstanceID != "" {
+		owner = "service instance " + destination.Owner.InstanceID
+	}
+	ui.Say("owner: %s", terminal.EntityNameColor(owner))
+	ui.Say("")
+	properties := destination.DestinationConfiguration.Map()
+	keys := keysOfMap(properties)
+	sort.Strings(keys)
+	table := ui.Table([]string{"property", "value"})
+	for _, key := range keys {
+		table.Add(key, properties[key])
+	}
+	table.Print()
+
+	// Display authentication tokens
+	ui.Say("")
+	ui.Say("Authentication tokens:")
+	if len(destination.AuthTokens) == 0 {
+		ui.Say("No authentication tokens resolved")
+	} else {
+		table = ui.Table([]string{"type", "expires in", "header", "error", "value"})
+		for _, authToken := range destination.AuthTokens {
+			header := "-"
+			if authToken.HTTPHeader != nil {
+				header = authToken.HTTPHeader.Key
+			}
+			expiresIn := "-"
+			if seconds, err := strconv.Atoi(authToken.ExpiresIn); err == nil {
+				expiresIn = strconv.Itoa(seconds) + "s"
+			}
+			authError := "-"
+			if authToken.Error != "" {
+				authError = terminal.FailureColor(authToken.Error)
+			}
+			table.Add(authToken.Type, expiresIn, header, authError, authToken.Value)
+		}
+		table.Print()
+	}
+
+	// Display certificates
+	ui.Say("")
+	ui.Say("Certificates:")
+	if len(destination.Certificates) == 0 {
+		ui.Say("No certificates resolved")
+	} else {
+		table = ui.Table([]string{"name", "type", "content"})
+		for _, certificate := range destination.Certificates {
+			table.Add(certificate.Name, certificate.Type, certificate.Content)
+		}
+		table.Print()
+	}
+
+	// Report authentication errors
+	if status == Failure {
+		ui.Say("")
+		ui.Warn("Destination service reported errors for authentication flow of destination %s", destinationName)
+	}
+
+	return status
+}
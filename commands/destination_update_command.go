@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"cf-cloud-connector/validation"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+const (
+	changeAdded   = "added"
+	changeChanged = "changed"
+	changeRemoved = "removed"
+)
+
+// DestinationUpdateCommand updates subaccount or service instance level
+// destination in place
+type DestinationUpdateCommand struct {
+	DestinationCommand
+}
+
+// DestinationPatch changes of destination attributes and properties
+type DestinationPatch struct {
+	Set   map[string]string
+	Unset []string
+}
+
+// destinationChange single change of destination attribute or property
+type destinationChange struct {
+	Property string `json:"property"`
+	Change   string `json:"change"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *DestinationUpdateCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-update",
+		HelpText: "Update destination on subaccount or destination service instance level in place",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-update DESTINATION_NAME [--set KEY=VALUE]... [--unset KEY]... [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"DESTINATION_NAME":                  "Name of destination to update",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"KEY":                               "Name of destination attribute or property, e.g. URL, ProxyType, sap-client",
+				"-set":                              "Set destination attribute or property in KEY=VALUE format. May be specified multiple times",
+				"-unset":                            "Remove destination attribute or property. May be specified multiple times",
+				"-destination-instance, -di":        "Update destination on level of destination service instance with specified name instead of subaccount level",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of changes: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *DestinationUpdateCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var setProperties stringSlice
+	var unsetProperties stringSlice
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.Var(&setProperties, "set", "")
+	flagSet.Var(&unsetProperties, "unset", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) == 0 {
+		ui.Failed("Destination name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if len(positional) > 1 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if len(setProperties) == 0 && len(unsetProperties) == 0 {
+		ui.Failed("Nothing to update, use --set or --unset flags. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	patch := DestinationPatch{Set: make(map[string]string), Unset: unsetProperties}
+	for _, property := range setProperties {
+		keyValue := strings.SplitN(property, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			ui.Failed("Property %q is not in KEY=VALUE format", property)
+			return Failure
+		}
+		patch.Set[keyValue[0]] = keyValue[1]
+	}
+	for _, key := range append(patch.Unset, keysOfMap(patch.Set)...) {
+		if key == "Name" {
+			ui.Failed("Destination name can not be changed")
+			return Failure
+		}
+	}
+	for _, key := range patch.Unset {
+		if _, ok := patch.Set[key]; ok {
+			ui.Failed("Property %s can not be set and unset at the same time", key)
+			return Failure
+		}
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.UpdateDestination(positional[0], patch, destinationInstanceName)
+}
+
+// UpdateDestination fetches destination, applies patch to it and replaces
+// destination on subaccount level or, if destination service instance name
+// is provided, on level of this service instance
+func (c *DestinationUpdateCommand) UpdateDestination(destinationName string, patch DestinationPatch, destinationInstanceName string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		levelMessage = " in destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	ui.Say("Updating destination %s%s in org %s / space %s as %s...",
+		terminal.EntityNameColor(destinationName),
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destination context
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get current destination configuration
+	destinations, err := listDestinations(destinationContext, destinationInstanceName != "")
+	if err != nil {
+		ui.Failed("Could not get list of destinations: %s", err.Error())
+		return Failure
+	}
+	var current *models.DestinationConfiguration
+	for idx := range destinations {
+		if destinations[idx].Name == destinationName {
+			current = &destinations[idx]
+			break
+		}
+	}
+	if current == nil {
+		ui.Failed("Could not find destination %s", destinationName)
+		return Failure
+	}
+
+	// Apply patch
+	updated := applyDestinationPatch(*current, patch)
+	changes := diffDestinations(*current, updated)
+	if len(changes) == 0 {
+		ui.Ok()
+		ui.Say("")
+		ui.Say("Destination %s is already up to date", terminal.EntityNameColor(destinationName))
+		return Success
+	}
+	issues := validation.Validate(updated)
+	if validation.HasErrors(issues) {
+		ui.Failed("Could not update destination %s: %s", destinationName, joinIssues(validation.Errors(issues)))
+		return Failure
+	}
+	for _, warning := range validation.Warnings(issues) {
+		ui.Warn("Warning: %s", warning.Error())
+	}
+
+	ui.Ok()
+	ui.Say("")
+
+	// Display changes, secrets are never printed
+	output := ui.NewOutput([]string{"property", "change", "old value", "new value"})
+	for _, change := range changes {
+		output.Add(change.Property, change.Change, change.OldValue, change.NewValue)
+	}
+	if err = output.Print(changes); err != nil {
+		ui.Failed("Could not print changes: %s", err.Error())
+		return Failure
+	}
+	ui.Say("")
+
+	// Update destination
+	ui.Say("Applying changes to destination %s...", terminal.EntityNameColor(destinationName))
+	err = updateDestination(destinationContext, destinationInstanceName != "", updated)
+	if err != nil {
+		ui.Failed("Could not update destination %s: %s", destinationName, err.Error())
+		return Failure
+	}
+
+	ui.Ok()
+	return Success
+}
+
+// applyDestinationPatch returns copy of destination with patch applied
+func applyDestinationPatch(destination models.DestinationConfiguration, patch DestinationPatch) models.DestinationConfiguration {
+	destination = destination.Copy()
+	for _, key := range patch.Unset {
+		destination.UnsetProperty(key)
+	}
+	for key, value := range patch.Set {
+		destination.SetProperty(key, value)
+	}
+	return destination
+}
+
+// diffDestinations returns changes between old and new destination, sorted
+// by property name. Values of secret properties are redacted
+func diffDestinations(oldDestination models.DestinationConfiguration, newDestination models.DestinationConfiguration) []destinationChange {
+	redactedOld := oldDestination.Redacted()
+	redactedNew := newDestination.Redacted()
+	oldMap := oldDestination.Map()
+	newMap := newDestination.Map()
+	redactedOldMap := redactedOld.Map()
+	redactedNewMap := redactedNew.Map()
+
+	changes := make([]destinationChange, 0)
+	for key, oldValue := range oldMap {
+		newValue, ok := newMap[key]
+		if !ok {
+			changes = append(changes, destinationChange{Property: key, Change: changeRemoved, OldValue: redactedOldMap[key]})
+		} else if newValue != oldValue {
+			changes = append(changes, destinationChange{Property: key, Change: changeChanged, OldValue: redactedOldMap[key], NewValue: redactedNewMap[key]})
+		}
+	}
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			changes = append(changes, destinationChange{Property: key, Change: changeAdded, NewValue: redactedNewMap[key]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Property < changes[j].Property
+	})
+	return changes
+}
+
+// keysOfMap returns keys of map
+func keysOfMap(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
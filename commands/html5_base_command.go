@@ -321,7 +321,7 @@ func (ctx *HTML5Context) GetRuntimeURL(runtime string) string {
 type stringSlice []string
 
 func (i *stringSlice) String() string {
-	return fmt.Sprintf("%d", *i)
+	return strings.Join(*i, ",")
 }
 
 func (i *stringSlice) Set(value string) error {
@@ -4,8 +4,10 @@ import (
 	clients "cf-cloud-connector/clients"
 	"cf-cloud-connector/clients/models"
 	"cf-cloud-connector/log"
+	"cf-cloud-connector/query"
 	"cf-cloud-connector/ui"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/cloudfoundry/cli/cf/terminal"
@@ -25,7 +27,7 @@ func (c *ListCommand) GetPluginCommand() plugin.Command {
 		Name:     "cloud-connector-list-HTML",
 		HelpText: "Display list of HTML5 applications or file paths of specified application",
 		UsageDetails: plugin.Usage{
-			Usage: "cf html5-list [APP_NAME] [APP_VERSION] [APP_HOST_ID|-n APP_HOST_NAME] [-d|-di DESTINATION_SERVICE_INSTANCE_NAME|-a CF_APP_NAME [-rt RUNTIME] [-u]]",
+			Usage: "cf html5-list [APP_NAME] [APP_VERSION] [APP_HOST_ID|-n APP_HOST_NAME] [-d|-di DESTINATION_SERVICE_INSTANCE_NAME|-a CF_APP_NAME [-rt RUNTIME] [-u]] [--filter EXPRESSION] [--sort FIELD[,-FIELD...]] [--output FORMAT]",
 			Options: map[string]string{
 				"APP_NAME":                          "Application name, which file paths should be listed. If not provided, list of applications will be printed",
 				"APP_VERSION":                       "Application version, which file paths should be listed. If not provided, current active version will be used",
@@ -38,6 +40,9 @@ func (c *ListCommand) GetPluginCommand() plugin.Command {
 				"-app, -a":                          "Cloud Foundry application name, which is bound to services that expose UI via html5-apps-repo",
 				"-runtime, -rt":                     "Runtime service for which conventional URLs of applications will be shown. Default value is 'cpp'",
 				"-url, -u":                          "Show conventional URLs of applications, when accessed via Cloud Foundry application specified with --app flag or when --destination or --destination-instance flag is used",
+				"-filter":                           "List only applications matching filter expression with conditions FIELD=VALUE, FIELD!=VALUE, FIELD~REGEXP or FIELD!~REGEXP joined with 'and' or 'or'. Fields: name, version, app-host-id, service-instance, visibility, last-changed",
+				"-sort":                             "Comma-separated list of fields to sort applications by. Fields prefixed with '-' are sorted in descending order",
+				"-output, -o":                       "Output format: table, json, yaml or csv. Default value is 'table'",
 			},
 		},
 	}
@@ -47,17 +52,41 @@ func (c *ListCommand) GetPluginCommand() plugin.Command {
 func (c *ListCommand) Execute(args []string) ExecutionStatus {
 	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
 
+	var filterExpression string
+	var sortExpression string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	flagSet.StringVar(&filterExpression, "filter", "", "")
+	flagSet.StringVar(&sortExpression, "sort", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf html5-list --help] for more details", err.Error())
+		return Failure
+	}
+	appFilter, err := query.ParseFilter(filterExpression)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+	appSort, err := query.ParseSort(sortExpression)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
 	// List apps in the space
-	if len(args) == 0 {
-		return c.ListApps(nil)
+	if len(positional) == 0 {
+		return c.ListApps(nil, appFilter, appSort)
 	}
 
 	ui.Failed("Too many arguments. See [cf html5-list --help] for more details")
 	return Failure
 }
 
-// ListApps get list of applications for given app-host-id or current space
-func (c *ListCommand) ListApps(appHostGUID *string) ExecutionStatus {
+// ListApps get list of applications for given app-host-id or current space,
+// which match filter, sorted by sort expression
+func (c *ListCommand) ListApps(appHostGUID *string, appFilter *query.Filter, appSort *query.Sort) ExecutionStatus {
 	// Get context
 	log.Tracef("Getting context (org/space/username)\n")
 	context, err := c.GetContext()
@@ -153,42 +182,100 @@ func (c *ListCommand) ListApps(appHostGUID *string) ExecutionStatus {
 	ui.Ok()
 	ui.Say("")
 
-	// Display information about HTML5 applications
-	table := ui.Table([]string{"name", "version", "app-host-id", "service instance", "visibility", "last changed"})
-	for _, service := range data.Services {
+	// Build rows of applications list
+	fields := []string{"name", "version", "app-host-id", "service-instance", "visibility", "last-changed"}
+	rows := make([]appRow, 0)
+	for serviceIdx, service := range data.Services {
 		if len(service.Apps) == 0 {
-			table.Add("-", "-", service.GUID, service.Name, "-", service.UpdatedAt)
+			rows = append(rows, appRow{service: serviceIdx, app: -1, values: query.MapRow{
+				"name":             "-",
+				"version":          "-",
+				"app-host-id":      service.GUID,
+				"service-instance": service.Name,
+				"visibility":       "-",
+				"last-changed":     service.UpdatedAt,
+			}})
 		} else {
-			for _, app := range service.Apps {
-				table.Add(app.Name, app.Version, service.GUID, service.Name, (map[bool]string{true: "public", false: "private"})[app.Public], app.Changed)
+			for appIdx, app := range service.Apps {
+				rows = append(rows, appRow{service: serviceIdx, app: appIdx, values: query.MapRow{
+					"name":             app.Name,
+					"version":          app.Version,
+					"app-host-id":      service.GUID,
+					"service-instance": service.Name,
+					"visibility":       (map[bool]string{true: "public", false: "private"})[app.Public],
+					"last-changed":     app.Changed,
+				}})
 			}
 		}
 	}
-	table.Print()
+	sort.SliceStable(rows, func(i, j int) bool {
+		return appSort.Less(rows[i].values, rows[j].values)
+	})
+
+	// Display information about HTML5 applications. Model contains only
+	// matching applications, in sort order
+	output := ui.NewOutput([]string{"name", "version", "app-host-id", "service instance", "visibility", "last changed"})
+	var matchingData Model
+	matchingData.Services = make([]Service, 0)
+	serviceIndexes := make(map[int]int)
+	for _, row := range rows {
+		if !appFilter.Matches(row.values) {
+			continue
+		}
+		values := make([]string, 0, len(fields))
+		for _, field := range fields {
+			values = append(values, row.values[field])
+		}
+		output.Add(values...)
+
+		idx, ok := serviceIndexes[row.service]
+		if !ok {
+			service := data.Services[row.service]
+			service.Apps = make([]App, 0)
+			matchingData.Services = append(matchingData.Services, service)
+			idx = len(matchingData.Services) - 1
+			serviceIndexes[row.service] = idx
+		}
+		if row.app >= 0 {
+			matchingData.Services[idx].Apps = append(matchingData.Services[idx].Apps, data.Services[row.service].Apps[row.app])
+		}
+	}
+	if err = output.Print(matchingData); err != nil {
+		ui.Failed("Could not print list of applications: %s", err.Error())
+		return Failure
+	}
 
 	return Success
 }
 
 // App app struct
 type App struct {
-	Name    string
-	Version string
-	Changed string
-	Public  bool
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Changed string `json:"changed"`
+	Public  bool   `json:"public"`
 }
 
 // Service service struct
 type Service struct {
-	UpdatedAt string
-	Name      string
-	GUID      string
-	Apps      []App
-	Prefix    string
+	UpdatedAt string `json:"updatedAt"`
+	Name      string `json:"name"`
+	GUID      string `json:"guid"`
+	Apps      []App  `json:"apps"`
+	Prefix    string `json:"prefix,omitempty"`
 }
 
 // Model model struct
 type Model struct {
-	Services []Service
+	Services []Service `json:"services"`
+}
+
+// appRow row of applications list, referencing service and application
+// of model. Services without applications are referenced with app index -1
+type appRow struct {
+	service int
+	app     int
+	values  query.MapRow
 }
 
 // indexOfString returns index of string in array or -1 if not found
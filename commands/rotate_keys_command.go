@@ -0,0 +1,332 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients"
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// RotateKeysCommand creates fresh service keys of destination and
+// connectivity service instances and deletes older keys
+type RotateKeysCommand struct {
+	DestinationCommand
+}
+
+// KeyRotationStep single step of service key rotation with its result
+type KeyRotationStep struct {
+	ServiceInstance string `json:"serviceInstance"`
+	Step            string `json:"step"`
+	ServiceKey      string `json:"serviceKey"`
+	Result          string `json:"result"`
+}
+
+// Services, which service keys are rotated
+var rotatedServiceNames = []string{"destination", "connectivity"}
+
+// Supported credential types of rotated service keys
+const (
+	credentialTypeBindingSecret = "binding-secret"
+	credentialTypeX509          = "x509"
+)
+
+// GetPluginCommand returns the plugin command details
+func (c *RotateKeysCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-rotate-keys",
+		HelpText: "Rotate service keys of destination and connectivity service instances",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-rotate-keys [-si SERVICE_INSTANCE_NAME]... [--keep N] [--credential-type binding-secret|x509] [--validity DAYS] [-f] [--output FORMAT]",
+			Options: map[string]string{
+				"SERVICE_INSTANCE_NAME":  "Name of destination or connectivity service instance",
+				"-service-instance, -si": "Rotate service keys only of service instance with specified name. By default service keys of all destination and connectivity service instances in the space are rotated",
+				"-keep":                  "Number of the most recent older service keys to keep in addition to the new one. Default value is 0",
+				"-credential-type":       "Credential type of new service keys: binding-secret or x509. By default credential type of service is used",
+				"-validity":              "Validity of x509 service key certificates in days. Default value is 365",
+				"-force, -f":             "Force rotation without confirmation",
+				"-output, -o":            "Output format of rotation steps: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *RotateKeysCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var serviceInstanceNames stringSlice
+	var keep int
+	var credentialType string
+	var validity int
+	var force bool
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	flagSet.Var(&serviceInstanceNames, "service-instance", "")
+	flagSet.Var(&serviceInstanceNames, "si", "")
+	flagSet.IntVar(&keep, "keep", 0, "")
+	flagSet.StringVar(&credentialType, "credential-type", "", "")
+	flagSet.IntVar(&validity, "validity", 365, "")
+	flagSet.BoolVar(&force, "force", false, "")
+	flagSet.BoolVar(&force, "f", false, "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if keep < 0 {
+		ui.Failed("Number of service keys to keep can not be negative. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if credentialType != "" && credentialType != credentialTypeBindingSecret && credentialType != credentialTypeX509 {
+		ui.Failed("Invalid credential type %q, expected binding-secret or x509. See [cf %s --help] for more details", credentialType, c.Name)
+		return Failure
+	}
+	if validity <= 0 {
+		ui.Failed("Validity of certificates must be positive number of days. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	return c.RotateKeys(serviceInstanceNames, keep, newServiceKeyParameters(credentialType, validity), force)
+}
+
+// newServiceKeyParameters returns parameters of service key with credential
+// type, or nil if default credential type of service is used
+func newServiceKeyParameters(credentialType string, validity int) interface{} {
+	switch credentialType {
+	case credentialTypeX509:
+		return map[string]interface{}{
+			"xsuaa": map[string]interface{}{
+				"credential-type": credentialTypeX509,
+				"x509": map[string]interface{}{
+					"key-length":    2048,
+					"validity":      validity,
+					"validity-type": "DAYS",
+				},
+			},
+		}
+	case credentialTypeBindingSecret:
+		return map[string]interface{}{
+			"xsuaa": map[string]interface{}{
+				"credential-type": credentialTypeBindingSecret,
+			},
+		}
+	}
+	return nil
+}
+
+// RotateKeys rotates service keys of service instances with provided names
+// or, if no names are provided, of all destination and connectivity service
+// instances in the space
+func (c *RotateKeysCommand) RotateKeys(serviceInstanceNames []string, keep int, parameters interface{}, force bool) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Rotating service keys of destination and connectivity service instances in org %s / space %s as %s...",
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Find service instances
+	serviceInstances, err := c.findRotatedServiceInstances(context, serviceInstanceNames)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Ask for confirmation
+	names := make([]string, 0, len(serviceInstances))
+	for _, serviceInstance := range serviceInstances {
+		names = append(names, serviceInstance.Name)
+	}
+	if !force && !ui.Confirm("Really rotate service keys of service instances %s?", terminal.EntityNameColor(strings.Join(names, ", "))) {
+		ui.Warn("Rotation cancelled")
+		return Success
+	}
+
+	// Rotate service keys
+	status := Success
+	steps := make([]KeyRotationStep, 0)
+	for _, serviceInstance := range serviceInstances {
+		instanceSteps, err := c.rotateServiceInstanceKeys(serviceInstance, keep, parameters)
+		steps = append(steps, instanceSteps...)
+		if err != nil {
+			status = Failure
+		}
+	}
+
+	if status == Success {
+		ui.Ok()
+	} else {
+		ui.Failed("Could not rotate service keys of some of service instances")
+	}
+	ui.Say("")
+
+	// Display steps
+	output := ui.NewOutput([]string{"service instance", "step", "service key", "result"})
+	for _, step := range steps {
+		result := step.Result
+		switch {
+		case result == "ok":
+			result = terminal.SuccessColor(result)
+		case strings.HasPrefix(result, "failed"):
+			result = terminal.FailureColor(result)
+		default:
+			result = terminal.WarningColor(result)
+		}
+		output.Add(step.ServiceInstance, step.Step, step.ServiceKey, result)
+	}
+	if err = output.Print(steps); err != nil {
+		ui.Failed("Could not print rotation steps: %s", err.Error())
+		return Failure
+	}
+
+	return status
+}
+
+// findRotatedServiceInstances finds destination and connectivity service
+// instances in the space. If names are provided, only service instances
+// with these names are returned
+func (c *DestinationCommand) findRotatedServiceInstances(context Context, serviceInstanceNames []string) ([]models.CFServiceInstance, error) {
+	log.Tracef("Getting list of services\n")
+	services, err := clients.GetServices(c.CliConnection)
+	if err != nil {
+		return nil, fmt.Errorf("could not get services: %s", err.Error())
+	}
+	servicePlans := make([]models.CFServicePlan, 0)
+	for _, service := range services {
+		if indexOfString(rotatedServiceNames, service.Name) < 0 {
+			continue
+		}
+		log.Tracef("Getting service plans for '%s' service (GUID: %s)\n", service.Name, service.GUID)
+		plans, err := clients.GetServicePlans(c.CliConnection, service.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get service plans of %s service: %s", service.Name, err.Error())
+		}
+		servicePlans = append(servicePlans, plans...)
+	}
+	if len(servicePlans) == 0 {
+		return nil, errors.New("destination and connectivity services are not in the list of available services")
+	}
+
+	serviceInstances, err := clients.GetServiceInstances(c.CliConnection, context.SpaceID, servicePlans)
+	if err != nil {
+		return nil, fmt.Errorf("could not get service instances: %s", err.Error())
+	}
+	if len(serviceInstanceNames) == 0 {
+		if len(serviceInstances) == 0 {
+			return nil, errors.New("could not find destination or connectivity service instances in the space")
+		}
+		return serviceInstances, nil
+	}
+
+	selectedServiceInstances := make([]models.CFServiceInstance, 0, len(serviceInstanceNames))
+	for _, name := range serviceInstanceNames {
+		found := false
+		for _, serviceInstance := range serviceInstances {
+			if serviceInstance.Name == name {
+				selectedServiceInstances = append(selectedServiceInstances, serviceInstance)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("could not find destination or connectivity service instance with name '%s'", name)
+		}
+	}
+	return selectedServiceInstances, nil
+}
+
+// rotateServiceInstanceKeys creates new service key of service instance,
+// verifies that it can obtain access token and deletes older service keys
+// except keep most recent ones. If verification fails, new service key is
+// deleted and older service keys are kept
+func (c *RotateKeysCommand) rotateServiceInstanceKeys(serviceInstance models.CFServiceInstance, keep int, parameters interface{}) ([]KeyRotationStep, error) {
+	steps := make([]KeyRotationStep, 0)
+	addStep := func(step string, serviceKey string, err error) {
+		result := "ok"
+		if err != nil {
+			result = "failed: " + err.Error()
+		}
+		steps = append(steps, KeyRotationStep{ServiceInstance: serviceInstance.Name, Step: step, ServiceKey: serviceKey, Result: result})
+	}
+
+	// Get older service keys before creating new one
+	log.Tracef("Getting service keys of %s service instance\n", serviceInstance.Name)
+	oldServiceKeys, err := clients.GetServiceKeys(c.CliConnection, serviceInstance.GUID)
+	if err != nil {
+		addStep("get service keys", "-", err)
+		return steps, err
+	}
+
+	// Create new service key
+	log.Tracef("Creating service key of %s service instance\n", serviceInstance.Name)
+	serviceKey, err := clients.CreateServiceKeyWithMetadata(c.CliConnection, serviceInstance.GUID, parameters, clients.RotatedKeyMetadata())
+	if err != nil {
+		addStep("create service key", "-", err)
+		return steps, err
+	}
+	addStep("create service key", serviceKey.Name, nil)
+
+	// Verify new service key
+	log.Tracef("Verifying service key %s\n", serviceKey.Name)
+	err = verifyServiceKey(*serviceKey)
+	addStep("verify token", serviceKey.Name, err)
+	if err != nil {
+		rollbackErr := clients.DeleteServiceKey(c.CliConnection, serviceKey.GUID, maxRetryCount)
+		addStep("roll back service key", serviceKey.Name, rollbackErr)
+		return steps, err
+	}
+
+	// Delete older service keys, except keep most recent ones
+	sort.SliceStable(oldServiceKeys, func(i, j int) bool {
+		return oldServiceKeys[i].CreatedAt > oldServiceKeys[j].CreatedAt
+	})
+	var status error
+	for idx, oldServiceKey := range oldServiceKeys {
+		if idx < keep {
+			steps = append(steps, KeyRotationStep{ServiceInstance: serviceInstance.Name, Step: "keep service key", ServiceKey: oldServiceKey.Name, Result: "kept"})
+			continue
+		}
+		log.Tracef("Deleting service key %s\n", oldServiceKey.Name)
+		err = clients.DeleteServiceKey(c.CliConnection, oldServiceKey.GUID, maxRetryCount)
+		addStep("delete service key", oldServiceKey.Name, err)
+		if err != nil {
+			status = err
+		}
+	}
+
+	return steps, status
+}
+
+// verifyServiceKey checks that access token can be obtained with credentials
+// of service key
+func verifyServiceKey(serviceKey models.CFServiceKey) error {
+	if serviceKey.Credentials.UAA == nil {
+		return errors.New("service key does not contain UAA credentials")
+	}
+	token, err := clients.GetToken(serviceKey.Credentials)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return errors.New("token service did not return access token")
+	}
+	return nil
+}
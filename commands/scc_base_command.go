@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients"
+	"cf-cloud-connector/clients/connector"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Environment variables with default connection settings of Cloud Connector
+const (
+	sccURLEnv      = "SCC_URL"
+	sccUserEnv     = "SCC_USER"
+	sccPasswordEnv = "SCC_PASSWORD"
+)
+
+// defaultSCCUser default administrator of Cloud Connector
+const defaultSCCUser = "Administrator"
+
+// SCCCommand base command for commands, which access Cloud Connector
+// administration REST API
+type SCCCommand struct {
+	DestinationCommand
+	// URL of Cloud Connector, e.g. https://localhost:8443
+	sccURL string
+	// Administrator of Cloud Connector
+	sccUser string
+	// File with CA certificate of Cloud Connector
+	sccCACertPath string
+	// Skip validation of Cloud Connector certificate
+	sccInsecure bool
+}
+
+// sccUsageOptions usage options of Cloud Connector connection flags
+var sccUsageOptions = map[string]string{
+	"SCC_URL":                  "URL of Cloud Connector administration, e.g. https://localhost:8443. Default value is taken from 'SCC_URL' environment variable",
+	"SCC_USER":                 "Cloud Connector administrator. Default value is taken from 'SCC_USER' environment variable or is 'Administrator'. Password is taken from 'SCC_PASSWORD' environment variable or prompted",
+	"CA_FILE":                  "PEM file with CA certificate of Cloud Connector",
+	"-scc-url":                 "Connect to Cloud Connector with specified URL",
+	"-scc-user":                "Authenticate as specified Cloud Connector administrator",
+	"-scc-ca-cert":             "Trust CA certificate of specified file in addition to system certificates",
+	"-scc-skip-ssl-validation": "Do not validate certificate of Cloud Connector",
+}
+
+// sccUsage usage of Cloud Connector connection flags
+const sccUsage = "[--scc-url SCC_URL] [--scc-user SCC_USER] [--scc-ca-cert CA_FILE] [--scc-skip-ssl-validation]"
+
+// withSCCUsageOptions returns usage options of command together with
+// usage options of Cloud Connector connection flags
+func withSCCUsageOptions(options map[string]string) map[string]string {
+	for name, description := range sccUsageOptions {
+		options[name] = description
+	}
+	return options
+}
+
+// addSCCFlags adds Cloud Connector connection flags to flag set of command
+func (c *SCCCommand) addSCCFlags(flagSet *flag.FlagSet) {
+	flagSet.StringVar(&c.sccURL, "scc-url", os.Getenv(sccURLEnv), "")
+	flagSet.StringVar(&c.sccUser, "scc-user", os.Getenv(sccUserEnv), "")
+	flagSet.StringVar(&c.sccCACertPath, "scc-ca-cert", "", "")
+	flagSet.BoolVar(&c.sccInsecure, "scc-skip-ssl-validation", false, "")
+}
+
+// NewConnectorClient creates client of Cloud Connector with connection
+// settings of flags. Password is prompted, if not set in environment
+func (c *SCCCommand) NewConnectorClient() (*connector.Client, error) {
+	if c.sccURL == "" {
+		return nil, fmt.Errorf("Cloud Connector URL is not provided. Use --scc-url flag or '%s' environment variable", sccURLEnv)
+	}
+	user := c.sccUser
+	if user == "" {
+		user = defaultSCCUser
+	}
+	password, ok := os.LookupEnv(sccPasswordEnv)
+	if !ok {
+		password = ui.AskForPassword("Password of Cloud Connector user %s", user)
+	}
+	customCAPath := c.sccCACertPath
+	if customCAPath == "" {
+		customCAPath = clients.CustomCAPath
+	}
+	log.Tracef("Connecting to Cloud Connector %s as %s\n", c.sccURL, user)
+	return connector.NewClient(c.sccURL, user, password, c.sccInsecure, customCAPath)
+}
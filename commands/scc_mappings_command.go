@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/scc"
+	"cf-cloud-connector/ui"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// SCCMappingsCommand generates Cloud Connector access control file with
+// system mappings of OnPremise destinations
+type SCCMappingsCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *SCCMappingsCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-scc-mappings",
+		HelpText: "Generate Cloud Connector access control file with system mappings of OnPremise destinations",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-scc-mappings -f FILE [--template TEMPLATE_FILE] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"FILE":                              "Access control file to write",
+				"TEMPLATE_FILE":                     "JSON file with 'defaults' and 'systemMappings', which values replace placeholders of internal host and port of system mappings with the same virtual host",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-file, -f":                         "Write system mappings to specified file",
+				"-template":                         "Fill system mappings with values of specified template file",
+				"-destination-instance, -di":        "Use only subaccount destinations and destinations of destination service instance with specified name",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of system mappings: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *SCCMappingsCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var fileName string
+	var templateFileName string
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&fileName, "file", "", "")
+	flagSet.StringVar(&fileName, "f", "", "")
+	flagSet.StringVar(&templateFileName, "template", "", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if fileName == "" {
+		ui.Failed("File name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	// Read template before destinations are fetched
+	var template scc.Template
+	if templateFileName != "" {
+		data, err := os.ReadFile(templateFileName)
+		if err != nil {
+			ui.Failed("Could not read template file %s: %s", templateFileName, err.Error())
+			return Failure
+		}
+		template, err = scc.ReadTemplate(data)
+		if err != nil {
+			ui.Failed("Could not parse template file %s: %s", templateFileName, err.Error())
+			return Failure
+		}
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.GenerateMappings(fileName, template, destinationInstanceName)
+}
+
+// GenerateMappings writes access control file with system mappings of
+// OnPremise destinations, filled with values of template
+func (c *SCCMappingsCommand) GenerateMappings(fileName string, template scc.Template, destinationInstanceName string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Generating Cloud Connector system mappings to %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(fileName),
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destinations
+	destinations, err := c.GetAllDestinations(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Derive system mappings
+	accessControl, mappingErrors := scc.FromDestinations(destinationConfigurations(destinations))
+	unresolved := accessControl.ApplyTemplate(template)
+
+	// Write file
+	data, err := json.MarshalIndent(accessControl, "", "    ")
+	if err != nil {
+		ui.Failed("Could not marshal system mappings: %s", err.Error())
+		return Failure
+	}
+	err = os.WriteFile(fileName, data, 0644)
+	if err != nil {
+		ui.Failed("Could not write file %s: %s", fileName, err.Error())
+		return Failure
+	}
+
+	ui.Ok()
+	ui.Say("")
+
+	// Display system mappings
+	output := ui.NewOutput([]string{"location", "virtual host", "virtual port", "protocol", "internal host", "internal port", "destinations"})
+	for _, location := range accessControl.Locations {
+		for _, mapping := range location.SystemMappings {
+			localHost, localPort := mapping.LocalHost, mapping.LocalPort
+			if localHost == scc.InternalHostPlaceholder {
+				localHost = terminal.WarningColor(localHost)
+			}
+			if localPort == scc.InternalPortPlaceholder {
+				localPort = terminal.WarningColor(localPort)
+			}
+			output.Add(locationName(location.LocationID), mapping.VirtualHost, mapping.VirtualPort, mapping.Protocol,
+				localHost, localPort, strings.Join(mapping.Destinations, ", "))
+		}
+	}
+	if err = output.Print(accessControl); err != nil {
+		ui.Failed("Could not print system mappings: %s", err.Error())
+		return Failure
+	}
+
+	// Report destinations, which could not be mapped, and placeholders
+	for _, mappingError := range mappingErrors {
+		ui.Warn("Warning: %s", mappingError.Error())
+	}
+	if unresolved > 0 {
+		ui.Warn("Internal host and port of %d system mappings are placeholders. Fill them in %s or use --template flag", unresolved, fileName)
+	}
+
+	return Success
+}
+
+// destinationConfigurations returns configurations of destinations
+func destinationConfigurations(destinations []DestinationItem) []models.DestinationConfiguration {
+	configurations := make([]models.DestinationConfiguration, 0, len(destinations))
+	for _, destination := range destinations {
+		configurations = append(configurations, destination.Destination)
+	}
+	return configurations
+}
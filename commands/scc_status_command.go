@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/ui"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// SCCStatusCommand prints subaccounts connected to Cloud Connector and
+// state of their tunnels
+type SCCStatusCommand struct {
+	SCCCommand
+}
+
+// SCCStatus Cloud Connector version and its subaccounts
+type SCCStatus struct {
+	URL         string                 `json:"url"`
+	Version     string                 `json:"version"`
+	Subaccounts []models.SCCSubaccount `json:"subaccounts"`
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *SCCStatusCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-scc-status",
+		Alias:    "scc-status",
+		HelpText: "Display subaccounts connected to Cloud Connector and health of their tunnels. Fails, if any tunnel is not connected",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-scc-status " + sccUsage + " [--output FORMAT]",
+			Options: withSCCUsageOptions(map[string]string{
+				"-output, -o": "Output format of subaccounts: table, json, yaml or csv. Default value is 'table'",
+			}),
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *SCCStatusCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addSCCFlags(flagSet)
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	return c.PrintStatus()
+}
+
+// PrintStatus prints Cloud Connector version and tunnel state of its
+// subaccounts
+func (c *SCCStatusCommand) PrintStatus() ExecutionStatus {
+	client, err := c.NewConnectorClient()
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	ui.Say("Getting status of Cloud Connector %s as %s...",
+		terminal.EntityNameColor(client.URL),
+		terminal.EntityNameColor(client.User))
+
+	// Get version
+	version, err := client.GetVersion()
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get subaccounts with tunnel details
+	subaccounts, err := client.GetSubaccounts()
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+	for idx, subaccount := range subaccounts {
+		details, err := client.GetSubaccount(subaccount.RegionHost, subaccount.Subaccount)
+		if err != nil {
+			ui.Failed(err.Error())
+			return Failure
+		}
+		subaccounts[idx].Tunnel = details.Tunnel
+		subaccounts[idx].SubaccountCertificate = details.SubaccountCertificate
+	}
+
+	ui.Ok()
+	ui.Say("")
+	ui.Say("Cloud Connector version: %s", terminal.EntityNameColor(version.Version))
+	ui.Say("")
+
+	// Display subaccounts
+	disconnected := 0
+	output := ui.NewOutput([]string{"subaccount", "display name", "region host", "location", "tunnel", "connected since", "connections", "application connections"})
+	for _, subaccount := range subaccounts {
+		tunnel := models.SCCTunnel{State: models.SCCTunnelDisconnected}
+		if subaccount.Tunnel != nil {
+			tunnel = *subaccount.Tunnel
+		}
+		state := terminal.SuccessColor(tunnel.State)
+		if tunnel.State != models.SCCTunnelConnected {
+			state = terminal.FailureColor(tunnel.State)
+			disconnected++
+		}
+		connectedSince := ""
+		if !tunnel.ConnectedSince().IsZero() {
+			connectedSince = tunnel.ConnectedSince().Format(time.RFC3339)
+		}
+		output.Add(subaccount.Subaccount, subaccount.DisplayName, subaccount.RegionHost, locationName(subaccount.LocationID),
+			state, connectedSince, strconv.Itoa(tunnel.Connections), strconv.Itoa(len(tunnel.ApplicationConnections)))
+	}
+	if err = output.Print(SCCStatus{URL: client.URL, Version: version.Version, Subaccounts: subaccounts}); err != nil {
+		ui.Failed("Could not print subaccounts: %s", err.Error())
+		return Failure
+	}
+
+	if disconnected > 0 {
+		ui.Say("")
+		ui.Failed("%d of %d subaccount tunnels are not connected", disconnected, len(subaccounts))
+		return Failure
+	}
+	return Success
+}
@@ -0,0 +1,290 @@
+package commands
+
+import (
+	"cf-cloud-connector/clients/connector"
+	"cf-cloud-connector/clients/models"
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/scc"
+	"cf-cloud-connector/ui"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// SCCSyncCommand synchronizes OnPremise destinations with system mappings
+// and resources of Cloud Connector subaccount
+type SCCSyncCommand struct {
+	SCCCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *SCCSyncCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-scc-sync",
+		Alias:    "scc-sync",
+		HelpText: "Plan and apply system mappings for OnPremise destinations and destinations for unused system mappings of Cloud Connector",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-scc-sync " + sccUsage + " [--subaccount SUBACCOUNT] [--template TEMPLATE_FILE] [--direction connector|destinations|both] [--apply] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: withSCCUsageOptions(map[string]string{
+				"SUBACCOUNT":                        "ID of subaccount connected to Cloud Connector. Required, if Cloud Connector is connected to multiple subaccounts",
+				"TEMPLATE_FILE":                     "JSON file with 'defaults' and 'systemMappings', which define internal host and port of system mappings with the same virtual host",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-subaccount":                       "Synchronize system mappings of specified subaccount",
+				"-template":                         "Take internal host and port of new system mappings from specified template file",
+				"-direction":                        "'connector' creates system mappings and resources, 'destinations' creates destinations, 'both' does both. Default value is 'both'",
+				"-apply":                            "Apply the plan. Without this flag only the plan is shown",
+				"-destination-instance, -di":        "Synchronize destinations of destination service instance with specified name instead of subaccount level",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of plan and result: table, json, yaml or csv. Default value is 'table'",
+			}),
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *SCCSyncCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var subaccountID string
+	var templateFileName string
+	var direction string
+	var apply bool
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	c.addSCCFlags(flagSet)
+	flagSet.StringVar(&subaccountID, "subaccount", "", "")
+	flagSet.StringVar(&templateFileName, "template", "", "")
+	flagSet.StringVar(&direction, "direction", scc.SyncBoth, "")
+	flagSet.BoolVar(&apply, "apply", false, "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if direction != scc.SyncToConnector && direction != scc.SyncToDestinations && direction != scc.SyncBoth {
+		ui.Failed("Invalid value of --direction flag: %q. Use 'connector', 'destinations' or 'both'", direction)
+		return Failure
+	}
+
+	// Read template before destinations are fetched
+	var template scc.Template
+	if templateFileName != "" {
+		data, err := os.ReadFile(templateFileName)
+		if err != nil {
+			ui.Failed("Could not read template file %s: %s", templateFileName, err.Error())
+			return Failure
+		}
+		template, err = scc.ReadTemplate(data)
+		if err != nil {
+			ui.Failed("Could not parse template file %s: %s", templateFileName, err.Error())
+			return Failure
+		}
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.SyncMappings(subaccountID, template, direction, apply, destinationInstanceName)
+}
+
+// SyncMappings plans system mappings and resources for OnPremise
+// destinations and destinations for unused system mappings, and applies
+// the plan if requested
+func (c *SCCSyncCommand) SyncMappings(subaccountID string, template scc.Template, direction string, apply bool, destinationInstanceName string) ExecutionStatus {
+	client, err := c.NewConnectorClient()
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	levelMessage := " on subaccount level"
+	if destinationInstanceName != "" {
+		levelMessage = " of destination service instance " + terminal.EntityNameColor(destinationInstanceName)
+	}
+
+	ui.Say("Synchronizing Cloud Connector %s with destinations%s in org %s / space %s as %s...",
+		terminal.EntityNameColor(client.URL),
+		levelMessage,
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Find subaccount
+	subaccount, err := findSCCSubaccount(client, subaccountID)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get system mappings with resources
+	location, err := getSCCLocation(client, subaccount)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Get destinations
+	destinationContext, err := c.GetDestinationContext(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+	destinations, err := listDestinations(destinationContext, destinationInstanceName != "")
+	if err != nil {
+		ui.Failed("Could not get list of destinations: %s", err.Error())
+		return Failure
+	}
+
+	// Build plan
+	plan := scc.PlanSync(destinations, location, template, direction)
+
+	ui.Ok()
+	ui.Say("")
+	ui.Say("Plan for subaccount %s, location %s:",
+		terminal.EntityNameColor(subaccount.Subaccount),
+		terminal.EntityNameColor(locationName(subaccount.LocationID)))
+
+	// In machine-readable output formats plan is printed only without --apply
+	if !ui.IsMachineOutput() || !apply {
+		output := ui.NewOutput([]string{"action", "virtual host", "virtual port", "protocol", "resource", "destination", "details"})
+		for _, item := range plan {
+			action := item.Action
+			if action == scc.SyncSkip {
+				action = terminal.WarningColor(action)
+			}
+			output.Add(action, item.VirtualHost, item.VirtualPort, item.Protocol, item.Resource, item.Destination, item.Details)
+		}
+		if err = output.Print(plan); err != nil {
+			ui.Failed("Could not print plan: %s", err.Error())
+			return Failure
+		}
+	}
+
+	if !apply {
+		ui.Say("")
+		ui.Say("No changes were applied. Use --apply flag to apply the plan")
+		return Success
+	}
+
+	// Apply plan
+	ui.Say("")
+	status := Success
+	succeeded := 0
+	failed := 0
+	results := make([]scc.SyncItem, 0, len(plan))
+	output := ui.NewOutput([]string{"action", "virtual host", "virtual port", "resource", "destination", "result"})
+	for _, item := range plan {
+		switch item.Action {
+		case scc.SyncCreateMapping:
+			err = createSCCMapping(client, subaccount, *item.Mapping)
+		case scc.SyncCreateResource:
+			err = client.CreateResource(subaccount.RegionHost, subaccount.Subaccount, item.Mapping.VirtualHost, item.Mapping.VirtualPort,
+				models.SCCResource{ID: item.Resource, Enabled: true, Description: "Used by destinations " + item.Destination})
+		case scc.SyncCreateDestination:
+			err = createDestination(destinationContext, destinationInstanceName != "", *item.Create)
+		case scc.SyncSkip:
+			item.Details = "skipped"
+			results = append(results, item)
+			output.Add(item.Action, item.VirtualHost, item.VirtualPort, item.Resource, item.Destination, "skipped")
+			continue
+		default:
+			err = errors.New(item.Details)
+		}
+		if err != nil {
+			status = Failure
+			failed++
+			item.Details = "failed: " + err.Error()
+			output.Add(item.Action, item.VirtualHost, item.VirtualPort, item.Resource, item.Destination, terminal.FailureColor(item.Details))
+		} else {
+			succeeded++
+			item.Details = "succeeded"
+			output.Add(item.Action, item.VirtualHost, item.VirtualPort, item.Resource, item.Destination, terminal.SuccessColor(item.Details))
+		}
+		results = append(results, item)
+	}
+	if err = output.Print(results); err != nil {
+		ui.Failed("Could not print result: %s", err.Error())
+		return Failure
+	}
+	ui.Say("")
+	ui.Say("%d succeeded, %d failed", succeeded, failed)
+
+	return status
+}
+
+// findSCCSubaccount returns subaccount of Cloud Connector with ID or the
+// only subaccount, if ID is empty
+func findSCCSubaccount(client *connector.Client, subaccountID string) (models.SCCSubaccount, error) {
+	subaccounts, err := client.GetSubaccounts()
+	if err != nil {
+		return models.SCCSubaccount{}, err
+	}
+	if subaccountID == "" {
+		if len(subaccounts) == 1 {
+			return subaccounts[0], nil
+		}
+		return models.SCCSubaccount{}, fmt.Errorf("Cloud Connector is connected to %d subaccounts. Use --subaccount flag to select one", len(subaccounts))
+	}
+	for _, subaccount := range subaccounts {
+		if subaccount.Subaccount == subaccountID {
+			return subaccount, nil
+		}
+	}
+	return models.SCCSubaccount{}, fmt.Errorf("subaccount %s is not connected to Cloud Connector", subaccountID)
+}
+
+// getSCCLocation returns system mappings of Cloud Connector subaccount
+// together with their resources
+func getSCCLocation(client *connector.Client, subaccount models.SCCSubaccount) (scc.Location, error) {
+	location := scc.Location{LocationID: subaccount.LocationID, SystemMappings: make([]scc.SystemMapping, 0)}
+	systemMappings, err := client.GetSystemMappings(subaccount.RegionHost, subaccount.Subaccount)
+	if err != nil {
+		return location, err
+	}
+	for _, systemMapping := range systemMappings {
+		var resources []models.SCCResource
+		if systemMapping.Protocol == scc.ProtocolHTTP || systemMapping.Protocol == scc.ProtocolHTTPS {
+			resources, err = client.GetResources(subaccount.RegionHost, subaccount.Subaccount, systemMapping.VirtualHost, systemMapping.VirtualPort)
+			if err != nil {
+				return location, err
+			}
+		}
+		location.SystemMappings = append(location.SystemMappings, scc.MappingFromConnector(systemMapping, resources))
+	}
+	return location, nil
+}
+
+// createSCCMapping creates system mapping of Cloud Connector subaccount
+// together with its resources
+func createSCCMapping(client *connector.Client, subaccount models.SCCSubaccount, mapping scc.SystemMapping) error {
+	err := client.CreateSystemMapping(subaccount.RegionHost, subaccount.Subaccount, mapping.ToConnector())
+	if err != nil {
+		return err
+	}
+	for _, resource := range mapping.Resources {
+		err = client.CreateResource(subaccount.RegionHost, subaccount.Subaccount, mapping.VirtualHost, mapping.VirtualPort, resource.ToConnector())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"cf-cloud-connector/log"
+	"cf-cloud-connector/scc"
+	"cf-cloud-connector/ui"
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// SCCVerifyCommand verifies OnPremise destinations against system mappings
+// of Cloud Connector access control file
+type SCCVerifyCommand struct {
+	DestinationCommand
+}
+
+// GetPluginCommand returns the plugin command details
+func (c *SCCVerifyCommand) GetPluginCommand() plugin.Command {
+	return plugin.Command{
+		Name:     "cloud-connector-scc-verify",
+		HelpText: "Verify OnPremise destinations against system mappings of Cloud Connector access control file",
+		UsageDetails: plugin.Usage{
+			Usage: "cf cloud-connector-scc-verify -f FILE [--location LOCATION_ID] [-di DESTINATION_SERVICE_INSTANCE_NAME] [--create-temp-instance] [--output FORMAT]",
+			Options: map[string]string{
+				"FILE":                              "Access control file with 'locations', or JSON array of system mappings exported from Cloud Connector",
+				"LOCATION_ID":                       "Location ID of Cloud Connector, which system mappings are exported as JSON array",
+				"DESTINATION_SERVICE_INSTANCE_NAME": "Name of destination service intance",
+				"-file, -f":                         "Read system mappings from specified file",
+				"-location, -l":                     "Assign system mappings of JSON array to specified location. Default location is used by default",
+				"-destination-instance, -di":        "Use only subaccount destinations and destinations of destination service instance with specified name",
+				"-create-temp-instance":             "Create temporary service instance of 'destination' service 'lite' plan, if there is none in the space, and delete it when command finishes",
+				"-output, -o":                       "Output format of findings: table, json, yaml or csv. Default value is 'table'",
+			},
+		},
+	}
+}
+
+// Execute executes plugin command
+func (c *SCCVerifyCommand) Execute(args []string) ExecutionStatus {
+	log.Tracef("Executing command '%s': args: '%v'\n", c.Name, args)
+
+	var fileName string
+	var locationID string
+	var destinationInstanceName string
+
+	// Parse arguments
+	flagSet := c.NewFlagSet()
+	c.addTempInstanceFlag(flagSet)
+	flagSet.StringVar(&fileName, "file", "", "")
+	flagSet.StringVar(&fileName, "f", "", "")
+	flagSet.StringVar(&locationID, "location", "", "")
+	flagSet.StringVar(&locationID, "l", "", "")
+	flagSet.StringVar(&destinationInstanceName, "destination-instance", "", "")
+	flagSet.StringVar(&destinationInstanceName, "di", "", "")
+	positional, err := parseFlags(flagSet, args)
+	if err != nil {
+		ui.Failed("%s. See [cf %s --help] for more details", err.Error(), c.Name)
+		return Failure
+	}
+	if len(positional) > 0 {
+		ui.Failed("Too many arguments. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+	if fileName == "" {
+		ui.Failed("File name is not provided. See [cf %s --help] for more details", c.Name)
+		return Failure
+	}
+
+	// Read access control file before destinations are fetched
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		ui.Failed("Could not read file %s: %s", fileName, err.Error())
+		return Failure
+	}
+	accessControl, err := scc.ReadAccessControl(data, locationID)
+	if err != nil {
+		ui.Failed("Could not parse file %s: %s", fileName, err.Error())
+		return Failure
+	}
+
+	defer c.CleanTemporaryResources()
+
+	return c.VerifyMappings(fileName, accessControl, destinationInstanceName)
+}
+
+// VerifyMappings prints OnPremise destinations without matching system
+// mapping and system mappings without destinations
+func (c *SCCVerifyCommand) VerifyMappings(fileName string, accessControl scc.AccessControl, destinationInstanceName string) ExecutionStatus {
+	// Get context
+	log.Tracef("Getting context (org/space/username)\n")
+	context, err := c.GetContext()
+	if err != nil {
+		ui.Failed("Could not get org and space: %s", err.Error())
+		return Failure
+	}
+
+	ui.Say("Verifying OnPremise destinations against system mappings of %s in org %s / space %s as %s...",
+		terminal.EntityNameColor(fileName),
+		terminal.EntityNameColor(context.Org),
+		terminal.EntityNameColor(context.Space),
+		terminal.EntityNameColor(context.Username))
+
+	// Get destinations
+	destinations, err := c.GetAllDestinations(context, destinationInstanceName)
+	if err != nil {
+		ui.Failed(err.Error())
+		return Failure
+	}
+
+	// Compare destinations with system mappings
+	findings := scc.Verify(accessControl, destinationConfigurations(destinations))
+	mismatches := 0
+	for _, finding := range findings {
+		if finding.Kind != scc.FindingUnusedMapping {
+			mismatches++
+		}
+	}
+	if mismatches > 0 {
+		ui.Failed("%d OnPremise destinations without matching system mapping", mismatches)
+	} else {
+		ui.Ok()
+	}
+	ui.Say("")
+
+	// Display findings
+	if len(findings) > 0 || ui.IsMachineOutput() {
+		output := ui.NewOutput([]string{"kind", "location", "virtual host", "virtual port", "protocol", "destination", "message"})
+		for _, finding := range findings {
+			kind := terminal.FailureColor(finding.Kind)
+			if finding.Kind == scc.FindingUnusedMapping {
+				kind = terminal.WarningColor(finding.Kind)
+			}
+			output.Add(kind, locationName(finding.LocationID), finding.VirtualHost, finding.VirtualPort,
+				finding.Protocol, finding.Destination, finding.Message)
+		}
+		if err = output.Print(findings); err != nil {
+			ui.Failed("Could not print findings: %s", err.Error())
+			return Failure
+		}
+		ui.Say("")
+	}
+	ui.Say("%d destinations checked, %d mismatches, %d unused system mappings",
+		len(destinations), mismatches, len(findings)-mismatches)
+
+	if mismatches > 0 {
+		return Failure
+	}
+	return Success
+}
@@ -1,3 +1,14 @@
 module cf-cloud-connector
 
 go 1.22.2
+
+require (
+	golang.org/x/crypto v0.11.0
+	gopkg.in/yaml.v2 v2.4.0
+	software.sslmate.com/src/go-pkcs12 v0.5.0
+)
+
+require (
+	golang.org/x/sys v0.10.0 // indirect
+	golang.org/x/term v0.10.0 // indirect
+)
@@ -29,6 +29,25 @@ type HTML5Plugin struct{}
 var Commands = []commands.Command{
 	&commands.ListCommand{},
 	&commands.DestinationListCommand{},
+	&commands.DestinationCreateCommand{},
+	&commands.DestinationDeleteCommand{},
+	&commands.DestinationImportCommand{},
+	&commands.DestinationExportCommand{},
+	&commands.DestinationUpdateCommand{},
+	&commands.DestinationResolveCommand{},
+	&commands.DestinationLintCommand{},
+	&commands.CertificateListCommand{},
+	&commands.CertificateUploadCommand{},
+	&commands.CertificateDownloadCommand{},
+	&commands.CertificateDeleteCommand{},
+	&commands.CleanupCommand{},
+	&commands.RotateKeysCommand{},
+	&commands.ConnectivityCommand{},
+	&commands.SCCMappingsCommand{},
+	&commands.SCCVerifyCommand{},
+	&commands.SCCStatusCommand{},
+	&commands.SCCSyncCommand{},
+	&commands.CertsExpiryCommand{},
 }
 
 // Run runs this plugin
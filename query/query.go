@@ -0,0 +1,276 @@
+// Package query implements filter and sort expressions of list commands,
+// e.g. "ProxyType=OnPremise and sap-client!=000 and Name~^A4H" and "Name,-URL"
+package query
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Row is a single row of list, which values may be accessed by field name
+type Row interface {
+	Get(field string) (string, bool)
+}
+
+// MapRow row backed by map. Fields are looked up case-insensitively
+type MapRow map[string]string
+
+// Get returns value of field
+func (r MapRow) Get(field string) (string, bool) {
+	if value, ok := r[field]; ok {
+		return value, true
+	}
+	for key, value := range r {
+		if strings.EqualFold(key, field) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Operators supported in filter conditions
+const (
+	OperatorEqual      = "="
+	OperatorNotEqual   = "!="
+	OperatorMatches    = "~"
+	OperatorNotMatches = "!~"
+)
+
+const (
+	keywordAnd           = "and"
+	keywordOr            = "or"
+	operatorCharacters   = "=!~"
+	quoteCharacters      = "'\""
+	whitespaceCharacters = " \t"
+)
+
+// Condition single condition of filter expression
+type Condition struct {
+	Field    string
+	Operator string
+	Value    string
+	regexp   *regexp.Regexp
+}
+
+// Filter parsed filter expression. Filter consists of conditions joined with
+// 'and' and 'or' keywords, where 'and' takes precedence over 'or'
+type Filter struct {
+	// Disjunction of conjunctions of conditions
+	alternatives [][]Condition
+}
+
+// ParseFilter parses filter expression. Empty expression results in nil
+// filter, which matches all rows
+func ParseFilter(expression string) (*Filter, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+	filter := &Filter{alternatives: [][]Condition{{}}}
+	rest := expression
+	for {
+		var condition Condition
+		var err error
+		condition, rest, err = parseCondition(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %s", expression, err.Error())
+		}
+		last := len(filter.alternatives) - 1
+		filter.alternatives[last] = append(filter.alternatives[last], condition)
+
+		rest = strings.TrimLeft(rest, whitespaceCharacters)
+		if rest == "" {
+			return filter, nil
+		}
+		keyword, remainder := splitWord(rest)
+		switch strings.ToLower(keyword) {
+		case keywordAnd:
+		case keywordOr:
+			filter.alternatives = append(filter.alternatives, []Condition{})
+		default:
+			return nil, fmt.Errorf("invalid filter %q: expected 'and' or 'or', but found %q", expression, keyword)
+		}
+		rest = remainder
+	}
+}
+
+// parseCondition parses condition in the beginning of expression and
+// returns it together with the rest of expression
+func parseCondition(expression string) (Condition, string, error) {
+	var condition Condition
+	rest := strings.TrimLeft(expression, whitespaceCharacters)
+
+	// Field
+	end := strings.IndexAny(rest, operatorCharacters+whitespaceCharacters)
+	if end <= 0 {
+		return condition, "", fmt.Errorf("expected field name at %q", rest)
+	}
+	condition.Field = rest[:end]
+	rest = strings.TrimLeft(rest[end:], whitespaceCharacters)
+
+	// Operator
+	for _, operator := range []string{OperatorNotEqual, OperatorNotMatches, OperatorEqual, OperatorMatches} {
+		if strings.HasPrefix(rest, operator) {
+			condition.Operator = operator
+			break
+		}
+	}
+	if condition.Operator == "" {
+		return condition, "", fmt.Errorf("expected one of operators =, !=, ~, !~ after field %q", condition.Field)
+	}
+	rest = strings.TrimLeft(rest[len(condition.Operator):], whitespaceCharacters)
+
+	// Value
+	if rest != "" && strings.ContainsAny(rest[:1], quoteCharacters) {
+		end = strings.Index(rest[1:], rest[:1])
+		if end < 0 {
+			return condition, "", fmt.Errorf("unterminated quoted value of field %q", condition.Field)
+		}
+		condition.Value = rest[1 : end+1]
+		rest = rest[end+2:]
+	} else {
+		condition.Value, rest = splitWord(rest)
+	}
+
+	// Compile regular expression
+	if condition.Operator == OperatorMatches || condition.Operator == OperatorNotMatches {
+		compiled, err := regexp.Compile(condition.Value)
+		if err != nil {
+			return condition, "", fmt.Errorf("invalid regular expression %q: %s", condition.Value, err.Error())
+		}
+		condition.regexp = compiled
+	}
+
+	return condition, rest, nil
+}
+
+// splitWord splits expression into the first word and the rest
+func splitWord(expression string) (string, string) {
+	end := strings.IndexAny(expression, whitespaceCharacters)
+	if end < 0 {
+		return expression, ""
+	}
+	return expression[:end], expression[end:]
+}
+
+// Matches checks if row matches filter. Nil filter matches all rows
+func (f *Filter) Matches(row Row) bool {
+	if f == nil {
+		return true
+	}
+	for _, conditions := range f.alternatives {
+		matches := true
+		for _, condition := range conditions {
+			if !condition.Matches(row) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches checks if row matches condition. Missing fields are treated as
+// fields with empty value. Equality is checked case-insensitively
+func (c Condition) Matches(row Row) bool {
+	value, _ := row.Get(c.Field)
+	switch c.Operator {
+	case OperatorEqual:
+		return strings.EqualFold(value, c.Value)
+	case OperatorNotEqual:
+		return !strings.EqualFold(value, c.Value)
+	case OperatorMatches:
+		return c.regexp.MatchString(value)
+	case OperatorNotMatches:
+		return !c.regexp.MatchString(value)
+	}
+	return false
+}
+
+// SortKey single field of sort expression
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// Sort parsed sort expression. Sort consists of comma-separated field names,
+// where fields prefixed with '-' are sorted in descending order
+type Sort struct {
+	keys []SortKey
+}
+
+// ParseSort parses sort expression. Empty expression results in nil sort,
+// which keeps original order of rows
+func ParseSort(expression string) (*Sort, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, nil
+	}
+	s := &Sort{}
+	for _, field := range strings.Split(expression, ",") {
+		key := SortKey{Field: strings.TrimSpace(field)}
+		if strings.HasPrefix(key.Field, "-") {
+			key.Descending = true
+			key.Field = strings.TrimSpace(key.Field[1:])
+		} else if strings.HasPrefix(key.Field, "+") {
+			key.Field = strings.TrimSpace(key.Field[1:])
+		}
+		if key.Field == "" {
+			return nil, fmt.Errorf("invalid sort %q: empty field name", expression)
+		}
+		s.keys = append(s.keys, key)
+	}
+	return s, nil
+}
+
+// Less reports whether row a should be sorted before row b. Numeric values
+// are compared as numbers, other values are compared case-insensitively
+func (s *Sort) Less(a Row, b Row) bool {
+	if s == nil {
+		return false
+	}
+	for _, key := range s.keys {
+		valueA, _ := a.Get(key.Field)
+		valueB, _ := b.Get(key.Field)
+		result := compare(valueA, valueB)
+		if result == 0 {
+			continue
+		}
+		if key.Descending {
+			return result > 0
+		}
+		return result < 0
+	}
+	return false
+}
+
+// compare compares two values as numbers, if both are finite numbers, or
+// as strings
+func compare(a string, b string) int {
+	numberA, okA := parseFinite(a)
+	numberB, okB := parseFinite(b)
+	if okA && okB {
+		switch {
+		case numberA < numberB:
+			return -1
+		case numberA > numberB:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// parseFinite parses finite number. Values like "inf" or "nan", which are
+// accepted by strconv.ParseFloat, are not numbers
+func parseFinite(value string) (float64, bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+		return 0, false
+	}
+	return number, true
+}
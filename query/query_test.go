@@ -0,0 +1,138 @@
+package query
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMapRowGet(t *testing.T) {
+	row := MapRow{"ProxyType": "OnPremise", "proxytype": "Internet", "sap-client": "001"}
+	testCases := []struct {
+		field string
+		value string
+		found bool
+	}{
+		{field: "ProxyType", value: "OnPremise", found: true},
+		{field: "proxytype", value: "Internet", found: true},
+		{field: "SAP-Client", value: "001", found: true},
+		{field: "URL", found: false},
+	}
+	for _, tc := range testCases {
+		value, found := row.Get(tc.field)
+		if value != tc.value || found != tc.found {
+			t.Errorf("Get(%q) is (%q, %t), expected (%q, %t)", tc.field, value, found, tc.value, tc.found)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	rows := []MapRow{
+		{"Name": "A4H_HTTP", "ProxyType": "OnPremise", "sap-client": "001", "Description": "ABAP system"},
+		{"Name": "A4H_RFC", "ProxyType": "OnPremise", "sap-client": "000"},
+		{"Name": "northwind", "ProxyType": "Internet", "Description": "OData 'demo' service"},
+		{"Name": "backend", "ProxyType": "Internet"},
+	}
+	testCases := []struct {
+		expression string
+		names      []string
+	}{
+		{expression: "", names: []string{"A4H_HTTP", "A4H_RFC", "northwind", "backend"}},
+		{expression: "ProxyType=OnPremise", names: []string{"A4H_HTTP", "A4H_RFC"}},
+		{expression: "proxytype = onpremise", names: []string{"A4H_HTTP", "A4H_RFC"}},
+		{expression: "sap-client!=000", names: []string{"A4H_HTTP", "northwind", "backend"}},
+		{expression: "Description=", names: []string{"A4H_RFC", "backend"}},
+		{expression: "Description='ABAP system'", names: []string{"A4H_HTTP"}},
+		{expression: `Description="OData 'demo' service"`, names: []string{"northwind"}},
+		{expression: "Description = 'ABAP system' or Name=backend", names: []string{"A4H_HTTP", "backend"}},
+		{expression: "Name~^A4H", names: []string{"A4H_HTTP", "A4H_RFC"}},
+		{expression: "Name!~^A4H", names: []string{"northwind", "backend"}},
+		{expression: "Name~'_(HTTP|RFC)$' and sap-client=001", names: []string{"A4H_HTTP"}},
+		{expression: "Name~(?i)^a4h_rfc$", names: []string{"A4H_RFC"}},
+		{expression: "ProxyType=Internet or ProxyType=OnPremise and sap-client=000", names: []string{"A4H_RFC", "northwind", "backend"}},
+		{expression: "ProxyType=OnPremise and sap-client=000 or Name=backend", names: []string{"A4H_RFC", "backend"}},
+		{expression: "ProxyType=OnPremise AND Name~RFC OR Name=northwind", names: []string{"A4H_RFC", "northwind"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			filter, err := ParseFilter(tc.expression)
+			if err != nil {
+				t.Fatalf("could not parse filter: %s", err)
+			}
+			names := make([]string, 0)
+			for _, row := range rows {
+				if filter.Matches(row) {
+					names = append(names, row["Name"])
+				}
+			}
+			if !reflect.DeepEqual(names, tc.names) {
+				t.Errorf("matched rows are %v, expected %v", names, tc.names)
+			}
+		})
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	for _, expression := range []string{
+		"ProxyType",
+		"=OnPremise",
+		"ProxyType<OnPremise",
+		"Description='ABAP system",
+		"ProxyType=OnPremise xor Name=backend",
+		"ProxyType=OnPremise and",
+		"Name~(",
+	} {
+		if _, err := ParseFilter(expression); err == nil {
+			t.Errorf("expected error for filter %q", expression)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	rows := []MapRow{
+		{"Name": "b", "timeout": "100", "version": "1.10"},
+		{"Name": "C", "timeout": "20", "version": "1.9"},
+		{"Name": "a", "timeout": "3", "version": "v2"},
+		{"Name": "d", "timeout": "inf", "version": "1.9"},
+	}
+	testCases := []struct {
+		expression string
+		names      []string
+	}{
+		{expression: "", names: []string{"b", "C", "a", "d"}},
+		{expression: "Name", names: []string{"a", "b", "C", "d"}},
+		{expression: "-name", names: []string{"d", "C", "b", "a"}},
+		{expression: "timeout", names: []string{"a", "C", "b", "d"}},
+		{expression: "-timeout", names: []string{"d", "b", "C", "a"}},
+		{expression: "version,-Name", names: []string{"b", "d", "C", "a"}},
+		{expression: "+version, Name", names: []string{"b", "C", "d", "a"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			rowSort, err := ParseSort(tc.expression)
+			if err != nil {
+				t.Fatalf("could not parse sort: %s", err)
+			}
+			sorted := make([]MapRow, len(rows))
+			copy(sorted, rows)
+			sort.SliceStable(sorted, func(i, j int) bool {
+				return rowSort.Less(sorted[i], sorted[j])
+			})
+			names := make([]string, 0, len(sorted))
+			for _, row := range sorted {
+				names = append(names, row["Name"])
+			}
+			if !reflect.DeepEqual(names, tc.names) {
+				t.Errorf("sorted rows are %v, expected %v", names, tc.names)
+			}
+		})
+	}
+}
+
+func TestParseSortInvalid(t *testing.T) {
+	for _, expression := range []string{",", "Name,", "-"} {
+		if _, err := ParseSort(expression); err == nil {
+			t.Errorf("expected error for sort %q", expression)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+// Package scc derives Cloud Connector system mappings from OnPremise
+// destinations and reads and writes access control files. Fields of system
+// mappings and resources are named as in Cloud Connector REST API
+package scc
+
+import (
+	"bytes"
+	"cf-cloud-connector/clients/models"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Placeholders of internal host and port of generated system mappings
+const (
+	InternalHostPlaceholder = "${INTERNAL_HOST}"
+	InternalPortPlaceholder = "${INTERNAL_PORT}"
+)
+
+// Protocols of system mappings
+const (
+	ProtocolHTTP  = "HTTP"
+	ProtocolHTTPS = "HTTPS"
+	ProtocolRFC   = "RFC"
+	ProtocolRFCS  = "RFCS"
+	ProtocolLDAP  = "LDAP"
+	ProtocolLDAPS = "LDAPS"
+	ProtocolTCP   = "TCP"
+	ProtocolTCPS  = "TCPS"
+)
+
+// schemeProtocols protocols and default ports of URL schemes
+var schemeProtocols = map[string]struct {
+	protocol string
+	port     string
+}{
+	"http":  {ProtocolHTTP, "80"},
+	"https": {ProtocolHTTPS, "443"},
+	"ldap":  {ProtocolLDAP, "389"},
+	"ldaps": {ProtocolLDAPS, "636"},
+	"tcp":   {ProtocolTCP, ""},
+	"tcps":  {ProtocolTCPS, ""},
+}
+
+// Resource resource (URL path) of HTTP system mapping
+type Resource struct {
+	ID             string `json:"id"`
+	Enabled        bool   `json:"enabled"`
+	ExactMatchOnly bool   `json:"exactMatchOnly"`
+	Description    string `json:"description,omitempty"`
+}
+
+// SystemMapping mapping of virtual host and port to internal host and port
+type SystemMapping struct {
+	VirtualHost        string     `json:"virtualHost"`
+	VirtualPort        string     `json:"virtualPort"`
+	LocalHost          string     `json:"localHost"`
+	LocalPort          string     `json:"localPort"`
+	Protocol           string     `json:"protocol"`
+	BackendType        string     `json:"backendType,omitempty"`
+	AuthenticationMode string     `json:"authenticationMode,omitempty"`
+	HostInHeader       string     `json:"hostInHeader,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	Resources          []Resource `json:"resources,omitempty"`
+	// Names of destinations using system mapping. Not part of access control file
+	Destinations []string `json:"-"`
+}
+
+// Location system mappings of Cloud Connector with location ID. Empty
+// location ID means default location
+type Location struct {
+	LocationID     string          `json:"locationId"`
+	SystemMappings []SystemMapping `json:"systemMappings"`
+}
+
+// AccessControl system mappings of Cloud Connectors by location
+type AccessControl struct {
+	Locations []Location `json:"locations"`
+}
+
+// TemplateMapping values of system mapping, which replace placeholders and
+// defaults of generated system mappings with the same virtual host. Empty
+// virtual port and missing location ID match any port and location
+type TemplateMapping struct {
+	LocationID *string `json:"locationId,omitempty"`
+	SystemMapping
+}
+
+// Template template of access control file with defaults for all system
+// mappings and values of specific system mappings
+type Template struct {
+	Defaults       SystemMapping     `json:"defaults"`
+	SystemMappings []TemplateMapping `json:"systemMappings"`
+}
+
+// Key returns virtual host and port, which identify system mapping
+func (m SystemMapping) Key() string {
+	return strings.ToLower(m.VirtualHost) + ":" + m.VirtualPort
+}
+
+// HasPlaceholders checks if internal host or port of system mapping is
+// still a placeholder
+func (m SystemMapping) HasPlaceholders() bool {
+	return m.LocalHost == InternalHostPlaceholder || m.LocalPort == InternalPortPlaceholder
+}
+
+// MappingFromDestination derives location ID and system mapping from
+// OnPremise destination. Internal host and port are placeholders
+func MappingFromDestination(destination models.DestinationConfiguration) (string, SystemMapping, error) {
+	locationID, ok := destination.CloudConnectorLocationID()
+	if !ok {
+		return "", SystemMapping{}, fmt.Errorf("destination %s is not OnPremise destination", destination.Name)
+	}
+	mapping := SystemMapping{
+		LocalHost:    InternalHostPlaceholder,
+		LocalPort:    InternalPortPlaceholder,
+		Destinations: []string{destination.Name},
+	}
+
+	// RFC destinations define host and system number in properties
+	if strings.EqualFold(destination.Type, "RFC") {
+		mapping.Protocol = ProtocolRFC
+		if sncMode, _ := destination.GetProperty("jco.client.snc_mode"); sncMode == "1" {
+			mapping.Protocol = ProtocolRFCS
+		}
+		mapping.BackendType = "abapSys"
+		if host, _ := destination.GetProperty("jco.client.ashost"); host != "" {
+			sysnr, _ := destination.GetProperty("jco.client.sysnr")
+			if sysnr == "" {
+				return "", SystemMapping{}, fmt.Errorf("destination %s does not define jco.client.sysnr", destination.Name)
+			}
+			mapping.VirtualHost, mapping.VirtualPort = host, "sapgw"+sysnr
+			return locationID, mapping, nil
+		}
+		if host, _ := destination.GetProperty("jco.client.mshost"); host != "" {
+			systemID, _ := destination.GetProperty("jco.client.r3name")
+			if systemID == "" {
+				return "", SystemMapping{}, fmt.Errorf("destination %s does not define jco.client.r3name", destination.Name)
+			}
+			mapping.VirtualHost, mapping.VirtualPort = host, "sapms"+systemID
+			return locationID, mapping, nil
+		}
+		return "", SystemMapping{}, fmt.Errorf("destination %s does not define jco.client.ashost or jco.client.mshost", destination.Name)
+	}
+
+	// Other destinations define virtual host and port in URL
+	parsedURL, err := url.Parse(destination.URL)
+	if err != nil {
+		return "", SystemMapping{}, fmt.Errorf("destination %s has invalid URL %q", destination.Name, destination.URL)
+	}
+	scheme, ok := schemeProtocols[strings.ToLower(parsedURL.Scheme)]
+	if !ok || parsedURL.Hostname() == "" {
+		return "", SystemMapping{}, fmt.Errorf("destination %s has unsupported URL %q", destination.Name, destination.URL)
+	}
+	mapping.VirtualHost = parsedURL.Hostname()
+	mapping.VirtualPort = parsedURL.Port()
+	if mapping.VirtualPort == "" {
+		mapping.VirtualPort = scheme.port
+	}
+	if mapping.VirtualPort == "" {
+		return "", SystemMapping{}, fmt.Errorf("destination %s does not define port in URL %q", destination.Name, destination.URL)
+	}
+	mapping.Protocol = scheme.protocol
+	mapping.BackendType = "nonSAPsys"
+	if mapping.Protocol == ProtocolHTTP || mapping.Protocol == ProtocolHTTPS {
+		mapping.HostInHeader = "VIRTUAL"
+		mapping.AuthenticationMode = "NONE_RESTRICTED"
+		if destination.Authentication == models.PrincipalPropagationType {
+			mapping.AuthenticationMode = "X509_GENERAL"
+		}
+		path := parsedURL.Path
+		if path == "" {
+			path = "/"
+		}
+		mapping.Resources = []Resource{{ID: path, Enabled: true}}
+	}
+	return locationID, mapping, nil
+}
+
+// FromDestinations derives system mappings of OnPremise destinations.
+// Destinations with the same location, virtual host and port share system
+// mapping. Errors are returned for destinations, which can not be mapped
+func FromDestinations(destinations []models.DestinationConfiguration) (AccessControl, []error) {
+	accessControl := AccessControl{Locations: make([]Location, 0)}
+	mappingErrors := make([]error, 0)
+	for _, destination := range destinations {
+		if _, ok := destination.CloudConnectorLocationID(); !ok {
+			continue
+		}
+		locationID, mapping, err := MappingFromDestination(destination)
+		if err != nil {
+			mappingErrors = append(mappingErrors, err)
+			continue
+		}
+		location := accessControl.location(locationID)
+		existing := location.mapping(mapping.Key())
+		if existing == nil {
+			location.SystemMappings = append(location.SystemMappings, mapping)
+			continue
+		}
+		if existing.Protocol != mapping.Protocol {
+			mappingErrors = append(mappingErrors, fmt.Errorf("destination %s uses %s protocol for %s, but destination %s uses %s protocol",
+				destination.Name, mapping.Protocol, mapping.Key(), existing.Destinations[0], existing.Protocol))
+			continue
+		}
+		if indexOf(existing.Destinations, destination.Name) < 0 {
+			existing.Destinations = append(existing.Destinations, destination.Name)
+		}
+		for _, resource := range mapping.Resources {
+			if existing.resource(resource.ID) == nil {
+				existing.Resources = append(existing.Resources, resource)
+			}
+		}
+	}
+	accessControl.Sort()
+	for _, location := range accessControl.Locations {
+		for idx := range location.SystemMappings {
+			location.SystemMappings[idx].Description = "Used by destinations " + strings.Join(location.SystemMappings[idx].Destinations, ", ")
+		}
+	}
+	return accessControl, mappingErrors
+}
+
+// ApplyTemplate replaces placeholders and defaults of system mappings with
+// values of template. Non-empty values of the first matching template
+// mapping are used. Number of system mappings, which still contain
+// placeholders, is returned
+func (ac *AccessControl) ApplyTemplate(template Template) int {
+	unresolved := 0
+	for _, location := range ac.Locations {
+		for idx := range location.SystemMappings {
+			mapping := &location.SystemMappings[idx]
+			mapping.overlay(template.Defaults)
+			for _, templateMapping := range template.SystemMappings {
+				if templateMapping.matches(location.LocationID, *mapping) {
+					mapping.overlay(templateMapping.SystemMapping)
+					break
+				}
+			}
+			if mapping.HasPlaceholders() {
+				unresolved++
+			}
+		}
+	}
+	return unresolved
+}
+
+// Sort sorts locations by location ID and system mappings and resources by
+// virtual host, port and path
+func (ac *AccessControl) Sort() {
+	sort.SliceStable(ac.Locations, func(i, j int) bool {
+		return ac.Locations[i].LocationID < ac.Locations[j].LocationID
+	})
+	for _, location := range ac.Locations {
+		sort.SliceStable(location.SystemMappings, func(i, j int) bool {
+			return location.SystemMappings[i].Key() < location.SystemMappings[j].Key()
+		})
+		for _, mapping := range location.SystemMappings {
+			sort.SliceStable(mapping.Resources, func(i, j int) bool {
+				return mapping.Resources[i].ID < mapping.Resources[j].ID
+			})
+		}
+	}
+}
+
+// ReadAccessControl parses access control file. System mappings exported
+// as JSON array, as returned by Cloud Connector REST API, are assigned to
+// location with locationID
+func ReadAccessControl(data []byte, locationID string) (AccessControl, error) {
+	var accessControl AccessControl
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var mappings []SystemMapping
+		if err := json.Unmarshal(data, &mappings); err != nil {
+			return accessControl, fmt.Errorf("invalid system mappings file: %s", err.Error())
+		}
+		accessControl.Locations = []Location{{LocationID: locationID, SystemMappings: mappings}}
+		return accessControl, nil
+	}
+	if err := json.Unmarshal(data, &accessControl); err != nil {
+		return accessControl, fmt.Errorf("invalid access control file: %s", err.Error())
+	}
+	return accessControl, nil
+}
+
+// ReadTemplate parses template of access control file
+func ReadTemplate(data []byte) (Template, error) {
+	var template Template
+	if err := json.Unmarshal(data, &template); err != nil {
+		return template, fmt.Errorf("invalid template file: %s", err.Error())
+	}
+	return template, nil
+}
+
+// location returns location with ID, which is added if needed
+func (ac *AccessControl) location(locationID string) *Location {
+	if location := ac.findLocation(locationID); location != nil {
+		return location
+	}
+	ac.Locations = append(ac.Locations, Location{LocationID: locationID, SystemMappings: make([]SystemMapping, 0)})
+	return &ac.Locations[len(ac.Locations)-1]
+}
+
+// mapping returns system mapping with key or nil
+func (l *Location) mapping(key string) *SystemMapping {
+	for idx := range l.SystemMappings {
+		if l.SystemMappings[idx].Key() == key {
+			return &l.SystemMappings[idx]
+		}
+	}
+	return nil
+}
+
+// resource returns resource with ID or nil
+func (m *SystemMapping) resource(id string) *Resource {
+	for idx := range m.Resources {
+		if m.Resources[idx].ID == id {
+			return &m.Resources[idx]
+		}
+	}
+	return nil
+}
+
+// overlay replaces values of system mapping with non-empty values of other
+// system mapping. Virtual host, port and protocol are kept
+func (m *SystemMapping) overlay(other SystemMapping) {
+	if other.LocalHost != "" {
+		m.LocalHost = other.LocalHost
+	}
+	if other.LocalPort != "" {
+		m.LocalPort = other.LocalPort
+	}
+	if other.BackendType != "" {
+		m.BackendType = other.BackendType
+	}
+	if other.AuthenticationMode != "" {
+		m.AuthenticationMode = other.AuthenticationMode
+	}
+	if other.HostInHeader != "" {
+		m.HostInHeader = other.HostInHeader
+	}
+	if other.Description != "" {
+		m.Description = other.Description
+	}
+	if len(other.Resources) > 0 {
+		m.Resources = other.Resources
+	}
+}
+
+// matches checks if template mapping applies to system mapping of location
+func (t TemplateMapping) matches(locationID string, mapping SystemMapping) bool {
+	return strings.EqualFold(t.VirtualHost, mapping.VirtualHost) &&
+		(t.VirtualPort == "" || t.VirtualPort == mapping.VirtualPort) &&
+		(t.LocationID == nil || *t.LocationID == locationID)
+}
+
+func indexOf(collection []string, value string) int {
+	for idx, currentValue := range collection {
+		if currentValue == value {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,250 @@
+package scc
+
+import (
+	"cf-cloud-connector/clients/models"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Actions of synchronization plan
+const (
+	SyncCreateMapping     = "create mapping"
+	SyncCreateResource    = "create resource"
+	SyncCreateDestination = "create destination"
+	SyncSkip              = "skip"
+)
+
+// Directions of synchronization
+const (
+	SyncToConnector    = "connector"
+	SyncToDestinations = "destinations"
+	SyncBoth           = "both"
+)
+
+// SyncItem planned action, which brings destinations and system mappings
+// of Cloud Connector in line
+type SyncItem struct {
+	Action      string                           `json:"action" yaml:"action"`
+	VirtualHost string                           `json:"virtualHost" yaml:"virtualHost"`
+	VirtualPort string                           `json:"virtualPort" yaml:"virtualPort"`
+	Protocol    string                           `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Resource    string                           `json:"resource,omitempty" yaml:"resource,omitempty"`
+	Destination string                           `json:"destination,omitempty" yaml:"destination,omitempty"`
+	Details     string                           `json:"details,omitempty" yaml:"details,omitempty"`
+	Mapping     *SystemMapping                   `json:"-" yaml:"-"`
+	Create      *models.DestinationConfiguration `json:"-" yaml:"-"`
+}
+
+// invalidNameCharacters characters, which are not allowed in destination names
+var invalidNameCharacters = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// PlanSync compares OnPremise destinations of location with system mappings
+// of Cloud Connector location. Depending on direction, system mappings and
+// resources are planned for destinations without them, and destinations are
+// planned for system mappings, which no destination uses. Internal host and
+// port of planned system mappings are taken from template
+func PlanSync(destinations []models.DestinationConfiguration, location Location, template Template, direction string) []SyncItem {
+	plan := make([]SyncItem, 0)
+
+	// Derive system mappings of destinations of location
+	locationDestinations := make([]models.DestinationConfiguration, 0)
+	for _, destination := range destinations {
+		if locationID, ok := destination.CloudConnectorLocationID(); ok && locationID == location.LocationID {
+			locationDestinations = append(locationDestinations, destination)
+		}
+	}
+	expected, mappingErrors := FromDestinations(locationDestinations)
+	expected.ApplyTemplate(template)
+	expectedLocation := expected.location(location.LocationID)
+
+	// Plan system mappings and resources missing in Cloud Connector
+	if direction != SyncToDestinations {
+		for _, mappingError := range mappingErrors {
+			plan = append(plan, SyncItem{Action: SyncSkip, Details: mappingError.Error()})
+		}
+		for idx := range expectedLocation.SystemMappings {
+			mapping := expectedLocation.SystemMappings[idx]
+			item := SyncItem{
+				VirtualHost: mapping.VirtualHost,
+				VirtualPort: mapping.VirtualPort,
+				Protocol:    mapping.Protocol,
+				Destination: strings.Join(mapping.Destinations, ", "),
+			}
+			actual := location.mapping(mapping.Key())
+			if actual == nil {
+				item.Action = SyncCreateMapping
+				item.Mapping = &mapping
+				if mapping.HasPlaceholders() {
+					item.Action = SyncSkip
+					item.Details = "internal host and port are not defined by template"
+				}
+				plan = append(plan, item)
+				continue
+			}
+			if !strings.EqualFold(actual.Protocol, mapping.Protocol) {
+				item.Action = SyncSkip
+				item.Details = fmt.Sprintf("system mapping uses %s protocol", actual.Protocol)
+				plan = append(plan, item)
+				continue
+			}
+			for _, resource := range mapping.Resources {
+				if actual.covers(resource.ID) {
+					continue
+				}
+				resourceItem := item
+				resourceItem.Action = SyncCreateResource
+				resourceItem.Resource = resource.ID
+				resourceItem.Mapping = actual
+				plan = append(plan, resourceItem)
+			}
+		}
+	}
+
+	// Plan destinations for system mappings, which no destination uses
+	if direction != SyncToConnector {
+		names := make([]string, 0, len(destinations))
+		for _, destination := range destinations {
+			names = append(names, destination.Name)
+		}
+		for _, mapping := range location.SystemMappings {
+			if expectedLocation.mapping(mapping.Key()) != nil {
+				continue
+			}
+			item := SyncItem{
+				Action:      SyncCreateDestination,
+				VirtualHost: mapping.VirtualHost,
+				VirtualPort: mapping.VirtualPort,
+				Protocol:    mapping.Protocol,
+			}
+			destination, err := DestinationFromMapping(location.LocationID, mapping)
+			if err != nil {
+				item.Action = SyncSkip
+				item.Details = err.Error()
+			} else if indexOf(names, destination.Name) >= 0 {
+				item.Action = SyncSkip
+				item.Destination = destination.Name
+				item.Details = "destination with the same name already exists"
+			} else {
+				item.Destination = destination.Name
+				item.Create = &destination
+				names = append(names, destination.Name)
+			}
+			plan = append(plan, item)
+		}
+	}
+	return plan
+}
+
+// DestinationFromMapping derives OnPremise destination from system mapping
+// of location. Destination is named after virtual host and port and does
+// not use authentication
+func DestinationFromMapping(locationID string, mapping SystemMapping) (models.DestinationConfiguration, error) {
+	destination := models.DestinationConfiguration{
+		Name:        invalidNameCharacters.ReplaceAllString(mapping.VirtualHost+"_"+mapping.VirtualPort, "_"),
+		Description: "Generated from Cloud Connector system mapping " + mapping.Key(),
+		ProxyType:   "OnPremise",
+	}
+	if locationID != "" {
+		destination.SetProperty(models.CloudConnectorLocationIDProperty, locationID)
+	}
+	switch strings.ToUpper(mapping.Protocol) {
+	case ProtocolHTTP, ProtocolHTTPS:
+		path := "/"
+		for _, resource := range mapping.Resources {
+			if resource.Enabled {
+				path = resource.ID
+				break
+			}
+		}
+		destination.Type = "HTTP"
+		destination.SetProperty("Authentication", models.NoAuthenticationType)
+		destination.URL = strings.ToLower(mapping.Protocol) + "://" + mapping.VirtualHost + ":" + mapping.VirtualPort + path
+	case ProtocolRFC, ProtocolRFCS:
+		destination.Type = "RFC"
+		switch {
+		case strings.HasPrefix(mapping.VirtualPort, "sapgw"):
+			destination.SetProperty("jco.client.ashost", mapping.VirtualHost)
+			destination.SetProperty("jco.client.sysnr", strings.TrimPrefix(mapping.VirtualPort, "sapgw"))
+		case strings.HasPrefix(mapping.VirtualPort, "sapms"):
+			destination.SetProperty("jco.client.mshost", mapping.VirtualHost)
+			destination.SetProperty("jco.client.r3name", strings.TrimPrefix(mapping.VirtualPort, "sapms"))
+		default:
+			return destination, fmt.Errorf("virtual port %s of RFC system mapping is not sapgw<NN> or sapms<SID>", mapping.VirtualPort)
+		}
+		if strings.EqualFold(mapping.Protocol, ProtocolRFCS) {
+			destination.SetProperty("jco.client.snc_mode", "1")
+		}
+	default:
+		return destination, fmt.Errorf("destinations for %s system mappings are not supported", mapping.Protocol)
+	}
+	return destination, nil
+}
+
+// MappingFromConnector converts system mapping and resources returned by
+// Cloud Connector REST API
+func MappingFromConnector(mapping models.SCCSystemMapping, resources []models.SCCResource) SystemMapping {
+	result := SystemMapping{
+		VirtualHost:        mapping.VirtualHost,
+		VirtualPort:        mapping.VirtualPort,
+		LocalHost:          mapping.LocalHost,
+		LocalPort:          mapping.LocalPort,
+		Protocol:           mapping.Protocol,
+		BackendType:        mapping.BackendType,
+		AuthenticationMode: mapping.AuthenticationMode,
+		HostInHeader:       mapping.HostInHeader,
+		Description:        mapping.Description,
+	}
+	for _, resource := range resources {
+		result.Resources = append(result.Resources, Resource{
+			ID:             resource.ID,
+			Enabled:        resource.Enabled,
+			ExactMatchOnly: resource.ExactMatchOnly,
+			Description:    resource.Description,
+		})
+	}
+	return result
+}
+
+// ToConnector converts system mapping to model of Cloud Connector REST API.
+// Resources are not part of the model
+func (m SystemMapping) ToConnector() models.SCCSystemMapping {
+	return models.SCCSystemMapping{
+		VirtualHost:        m.VirtualHost,
+		VirtualPort:        m.VirtualPort,
+		LocalHost:          m.LocalHost,
+		LocalPort:          m.LocalPort,
+		Protocol:           m.Protocol,
+		BackendType:        m.BackendType,
+		AuthenticationMode: m.AuthenticationMode,
+		HostInHeader:       m.HostInHeader,
+		Description:        m.Description,
+	}
+}
+
+// ToConnector converts resource to model of Cloud Connector REST API
+func (r Resource) ToConnector() models.SCCResource {
+	return models.SCCResource{
+		ID:             r.ID,
+		Enabled:        r.Enabled,
+		ExactMatchOnly: r.ExactMatchOnly,
+		Description:    r.Description,
+	}
+}
+
+// covers checks if enabled resource of system mapping grants access to
+// path. Resources, which are not exact match only, cover their sub-paths
+func (m *SystemMapping) covers(path string) bool {
+	for _, resource := range m.Resources {
+		if !resource.Enabled {
+			continue
+		}
+		if resource.ID == path {
+			return true
+		}
+		if !resource.ExactMatchOnly && strings.HasPrefix(path, strings.TrimSuffix(resource.ID, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package scc
+
+import (
+	"cf-cloud-connector/clients/models"
+	"fmt"
+	"strings"
+)
+
+// Kinds of verification findings
+const (
+	FindingMissingMapping   = "missing mapping"
+	FindingProtocolMismatch = "protocol mismatch"
+	FindingLocationMismatch = "location mismatch"
+	FindingUnmappable       = "unmappable destination"
+	FindingUnusedMapping    = "unused mapping"
+)
+
+// Finding mismatch between OnPremise destination and system mappings of
+// access control file
+type Finding struct {
+	Kind        string `json:"kind" yaml:"kind"`
+	LocationID  string `json:"locationId" yaml:"locationId"`
+	VirtualHost string `json:"virtualHost,omitempty" yaml:"virtualHost,omitempty"`
+	VirtualPort string `json:"virtualPort,omitempty" yaml:"virtualPort,omitempty"`
+	Protocol    string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	Destination string `json:"destination,omitempty" yaml:"destination,omitempty"`
+	Message     string `json:"message" yaml:"message"`
+}
+
+// Verify compares OnPremise destinations with system mappings of access
+// control file. Destinations without system mapping of the same location,
+// virtual host, port and protocol and system mappings without destinations
+// are reported
+func Verify(accessControl AccessControl, destinations []models.DestinationConfiguration) []Finding {
+	findings := make([]Finding, 0)
+	used := make(map[string]bool)
+	for _, destination := range destinations {
+		if _, ok := destination.CloudConnectorLocationID(); !ok {
+			continue
+		}
+		locationID, expected, err := MappingFromDestination(destination)
+		if err != nil {
+			findings = append(findings, Finding{
+				Kind:        FindingUnmappable,
+				LocationID:  locationID,
+				Destination: destination.Name,
+				Message:     err.Error(),
+			})
+			continue
+		}
+		finding := Finding{
+			LocationID:  locationID,
+			VirtualHost: expected.VirtualHost,
+			VirtualPort: expected.VirtualPort,
+			Protocol:    expected.Protocol,
+			Destination: destination.Name,
+		}
+
+		// Look for system mapping in location of destination
+		location := accessControl.findLocation(locationID)
+		if location != nil {
+			if actual := location.mapping(expected.Key()); actual != nil {
+				used[mappingID(locationID, *actual)] = true
+				if !strings.EqualFold(actual.Protocol, expected.Protocol) {
+					finding.Kind = FindingProtocolMismatch
+					finding.Message = fmt.Sprintf("system mapping %s uses %s protocol, but destination requires %s protocol",
+						expected.Key(), actual.Protocol, expected.Protocol)
+					findings = append(findings, finding)
+				}
+				continue
+			}
+		}
+
+		// Look for system mapping in other locations
+		otherLocations := make([]string, 0)
+		for idx := range accessControl.Locations {
+			other := &accessControl.Locations[idx]
+			if other.LocationID != locationID && other.mapping(expected.Key()) != nil {
+				otherLocations = append(otherLocations, locationDisplayName(other.LocationID))
+			}
+		}
+		if len(otherLocations) > 0 {
+			finding.Kind = FindingLocationMismatch
+			finding.Message = fmt.Sprintf("system mapping %s exists only for location %s, but destination uses location %s",
+				expected.Key(), strings.Join(otherLocations, ", "), locationDisplayName(locationID))
+		} else {
+			finding.Kind = FindingMissingMapping
+			finding.Message = fmt.Sprintf("no system mapping %s for location %s", expected.Key(), locationDisplayName(locationID))
+		}
+		findings = append(findings, finding)
+	}
+
+	// Report system mappings, which are not used by any destination
+	for _, location := range accessControl.Locations {
+		for _, mapping := range location.SystemMappings {
+			if used[mappingID(location.LocationID, mapping)] {
+				continue
+			}
+			findings = append(findings, Finding{
+				Kind:        FindingUnusedMapping,
+				LocationID:  location.LocationID,
+				VirtualHost: mapping.VirtualHost,
+				VirtualPort: mapping.VirtualPort,
+				Protocol:    mapping.Protocol,
+				Message:     fmt.Sprintf("system mapping %s is not used by any OnPremise destination", mapping.Key()),
+			})
+		}
+	}
+	return findings
+}
+
+// findLocation returns location with ID or nil
+func (ac *AccessControl) findLocation(locationID string) *Location {
+	for idx := range ac.Locations {
+		if ac.Locations[idx].LocationID == locationID {
+			return &ac.Locations[idx]
+		}
+	}
+	return nil
+}
+
+// mappingID identifies system mapping across locations
+func mappingID(locationID string, mapping SystemMapping) string {
+	return locationID + "/" + mapping.Key()
+}
+
+// locationDisplayName returns location ID or name of default location
+func locationDisplayName(locationID string) string {
+	if locationID == "" {
+		return "(default)"
+	}
+	return locationID
+}
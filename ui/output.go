@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/terminal"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Supported output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatCSV   = "csv"
+)
+
+var outputFormat = FormatTable
+
+// SetOutputFormat set output format of commands. Machine-readable formats
+// suppress progress messages
+func SetOutputFormat(format string) error {
+	switch strings.ToLower(format) {
+	case FormatTable, "":
+		outputFormat = FormatTable
+	case FormatJSON, FormatYAML, FormatCSV:
+		outputFormat = strings.ToLower(format)
+	default:
+		return fmt.Errorf("unsupported output format %q, use one of: table, json, yaml, csv", format)
+	}
+	return nil
+}
+
+// GetOutputFormat get output format of commands
+func GetOutputFormat() string {
+	return outputFormat
+}
+
+// IsMachineOutput checks if output format is machine-readable
+func IsMachineOutput() bool {
+	return outputFormat != FormatTable
+}
+
+// Output output of command, which is printed as table or CSV built from
+// rows, or as JSON or YAML built from underlying model
+type Output struct {
+	headers []string
+	rows    [][]string
+}
+
+// NewOutput creates output with table headers
+func NewOutput(headers []string) *Output {
+	return &Output{headers: headers, rows: make([][]string, 0)}
+}
+
+// Add add row
+func (o *Output) Add(row ...string) {
+	o.rows = append(o.rows, row)
+}
+
+// Print print output in current output format. Model is used for JSON and
+// YAML formats, and may be nil, if rows should be printed as list of objects
+func (o *Output) Print(model interface{}) error {
+	switch outputFormat {
+	case FormatJSON:
+		data, err := json.MarshalIndent(o.getModel(model), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, string(data))
+	case FormatYAML:
+		data, err := marshalYAML(o.getModel(model))
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(os.Stdout, string(data))
+	case FormatCSV:
+		writer := csv.NewWriter(os.Stdout)
+		writer.Write(o.headers)
+		for _, row := range o.rows {
+			values := make([]string, 0, len(row))
+			for _, value := range row {
+				values = append(values, terminal.Decolorize(value))
+			}
+			writer.Write(values)
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		table := ui.Table(o.headers)
+		for _, row := range o.rows {
+			table.Add(row...)
+		}
+		table.Print()
+	}
+	return nil
+}
+
+// getModel returns model or, if model is nil, rows as list of objects
+func (o *Output) getModel(model interface{}) interface{} {
+	if model != nil {
+		return model
+	}
+	objects := make([]map[string]string, 0, len(o.rows))
+	for _, row := range o.rows {
+		object := make(map[string]string)
+		for idx, header := range o.headers {
+			if idx < len(row) {
+				object[header] = terminal.Decolorize(row[idx])
+			}
+		}
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+// marshalYAML marshals model to YAML via JSON, so that JSON field names
+// and custom JSON marshalers of models are respected. Order of fields of
+// top-level objects is preserved
+func marshalYAML(model interface{}) ([]byte, error) {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(string(data), "[") {
+		var list []yaml.MapSlice
+		if err = yaml.Unmarshal(data, &list); err == nil {
+			return yaml.Marshal(list)
+		}
+	} else if strings.HasPrefix(string(data), "{") {
+		var object yaml.MapSlice
+		if err = yaml.Unmarshal(data, &object); err == nil {
+			return yaml.Marshal(object)
+		}
+	}
+	var value interface{}
+	if err = yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(value)
+}
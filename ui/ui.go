@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"bufio"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/terminal"
+	sshterminal "golang.org/x/crypto/ssh/terminal"
 )
 
 var teePrinter *terminal.TeePrinter
@@ -39,8 +43,11 @@ func PrintPaginator(rows []string, err error) {
 	ui.PrintPaginator(rows, err)
 }
 
-// Say say
+// Say say. Suppressed in machine-readable output formats
 func Say(message string, args ...interface{}) {
+	if IsMachineOutput() {
+		return
+	}
 	ui.Say(message, args...)
 }
 
@@ -49,28 +56,61 @@ func PrintCapturingNoOutput(message string, args ...interface{}) {
 	ui.PrintCapturingNoOutput(message, args...)
 }
 
-// Warn warning
+// Warn warning. Printed to stderr in machine-readable output formats
 func Warn(message string, args ...interface{}) {
+	if IsMachineOutput() {
+		fmt.Fprintf(os.Stderr, message+"\n", args...)
+		return
+	}
 	ui.Warn(message, args...)
 }
 
-// Ask ask
+// Ask ask. Prompt is printed to stderr in machine-readable output formats
 func Ask(prompt string, args ...interface{}) (answer string) {
+	if IsMachineOutput() {
+		fmt.Fprintf(os.Stderr, "\n"+prompt+"> ", args...)
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		return strings.TrimSpace(line)
+	}
 	return ui.Ask(prompt, args...)
 }
 
-// Confirm confirm
+// AskForPassword ask for password without echoing input. Prompt is printed
+// to stderr in machine-readable output formats
+func AskForPassword(prompt string, args ...interface{}) (answer string) {
+	if IsMachineOutput() {
+		fmt.Fprintf(os.Stderr, "\n"+prompt+"> ", args...)
+		password, _ := sshterminal.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		return string(password)
+	}
+	return ui.AskForPassword(prompt, args...)
+}
+
+// Confirm confirm. Prompt is printed to stderr in machine-readable output
+// formats
 func Confirm(message string, args ...interface{}) bool {
-	return ui.Confirm(message, args...)
+	switch strings.ToLower(Ask(message, args...)) {
+	case "y", "yes":
+		return true
+	}
+	return false
 }
 
-// Ok ok
+// Ok ok. Suppressed in machine-readable output formats
 func Ok() {
+	if IsMachineOutput() {
+		return
+	}
 	ui.Ok()
 }
 
-// Failed failed
+// Failed failed. Printed to stderr in machine-readable output formats
 func Failed(message string, args ...interface{}) {
+	if IsMachineOutput() {
+		fmt.Fprintf(os.Stderr, "FAILED\n"+message+"\n", args...)
+		return
+	}
 	ui.Failed(message, args...)
 }
 
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"bytes"
+	"cf-cloud-connector/clients/models"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// LintFile parses JSON file with array of destination configurations and
+// validates each of them. Issues refer to lines of file, where destination
+// or its invalid attribute is defined
+func LintFile(data []byte) ([]Issue, []models.DestinationConfiguration, error) {
+	issues := make([]Issue, 0)
+	destinations := make([]models.DestinationConfiguration, 0)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, syntaxError(data, err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, nil, fmt.Errorf("line 1: expected array of destinations")
+	}
+	for decoder.More() {
+		destinationLine := lineOfOffset(data, skipWhitespace(data, decoder.InputOffset()))
+		fieldLines := make(map[string]int)
+		object := make(map[string]json.RawMessage)
+
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, nil, syntaxError(data, err)
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '{' {
+			return nil, nil, fmt.Errorf("line %d: expected destination object", destinationLine)
+		}
+		for decoder.More() {
+			keyLine := lineOfOffset(data, skipWhitespace(data, decoder.InputOffset()))
+			token, err = decoder.Token()
+			if err != nil {
+				return nil, nil, syntaxError(data, err)
+			}
+			key, _ := token.(string)
+			var value json.RawMessage
+			if err = decoder.Decode(&value); err != nil {
+				return nil, nil, syntaxError(data, err)
+			}
+			fieldLines[key] = keyLine
+			object[key] = value
+		}
+		if _, err = decoder.Token(); err != nil {
+			return nil, nil, syntaxError(data, err)
+		}
+
+		// Decode destination from collected attributes
+		var destination models.DestinationConfiguration
+		objectData, err := json.Marshal(object)
+		if err == nil {
+			err = json.Unmarshal(objectData, &destination)
+		}
+		if err != nil {
+			issues = append(issues, Issue{Line: destinationLine, Severity: SeverityError, Message: err.Error()})
+			continue
+		}
+		destinations = append(destinations, destination)
+
+		for _, issue := range Validate(destination) {
+			issue.Line = destinationLine
+			if line, ok := fieldLines[issue.Field]; ok {
+				issue.Line = line
+			}
+			issues = append(issues, issue)
+		}
+	}
+	if _, err = decoder.Token(); err != nil && err != io.EOF {
+		return nil, nil, syntaxError(data, err)
+	}
+	return issues, destinations, nil
+}
+
+// syntaxError adds line number to JSON syntax error
+func syntaxError(data []byte, err error) error {
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		return fmt.Errorf("line %d: %s", lineOfOffset(data, syntaxErr.Offset), syntaxErr.Error())
+	}
+	if err == io.EOF {
+		return fmt.Errorf("line %d: unexpected end of file", lineOfOffset(data, int64(len(data))))
+	}
+	return err
+}
+
+// skipWhitespace returns offset of first non-whitespace character after
+// offset, skipping also separating comma
+func skipWhitespace(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// lineOfOffset returns 1-based line number of offset in data
+func lineOfOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,394 @@
+// Package validation checks destination configurations before they are sent
+// to destination service, with rules per destination type, proxy type and
+// authentication type
+package validation
+
+import (
+	"cf-cloud-connector/clients/models"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Severities of issues
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue single problem of destination configuration
+type Issue struct {
+	Destination string `json:"destination,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+}
+
+func (i Issue) Error() string {
+	if i.Field != "" {
+		return i.Field + ": " + i.Message
+	}
+	return i.Message
+}
+
+// Rule checks destination configuration and returns found issues
+type Rule func(destination models.DestinationConfiguration) []Issue
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,200}$`)
+
+var knownTypes = []string{"HTTP", "RFC", "MAIL", "LDAP", "TCP"}
+
+var knownProxyTypes = []string{"Internet", "OnPremise", "PrivateLink"}
+
+// rules common rules for all destinations
+var rules = []Rule{
+	checkName,
+	checkType,
+	checkURL,
+	checkProxyType,
+	checkAuthentication,
+	checkRedactedValues,
+}
+
+// proxyTypeRules rules per proxy type of HTTP destinations
+var proxyTypeRules = map[string][]Rule{
+	"OnPremise": {checkOnPremiseURL},
+	"Internet":  {checkInternetURL},
+}
+
+// authenticationRules rules per authentication type of HTTP destinations
+var authenticationRules = map[string][]Rule{
+	models.NoAuthenticationType:                {},
+	models.BasicAuthenticationType:             {checkBasicAuthentication},
+	models.PrincipalPropagationType:            {requireProxyType("OnPremise")},
+	models.OAuth2ClientCredentialsType:         {checkOAuth2ClientCredentials},
+	models.OAuth2SAMLBearerAssertionType:       {checkOAuth2SAMLBearerAssertion},
+	models.OAuth2UserTokenExchangeType:         {checkOAuth2UserTokenExchange},
+	models.OAuth2JWTBearerType:                 {checkOAuth2JWTBearer},
+	models.OAuth2PasswordType:                  {checkOAuth2Password},
+	models.SAMLAssertionType:                   {checkSAMLAssertion},
+	models.ClientCertificateAuthenticationType: {checkClientCertificateAuthentication},
+}
+
+// Validate checks destination configuration and returns found issues
+func Validate(destination models.DestinationConfiguration) []Issue {
+	issues := make([]Issue, 0)
+	for _, rule := range rules {
+		issues = append(issues, rule(destination)...)
+	}
+	if destination.Type == "HTTP" {
+		// Authentication rules check typed Auth, which has to match
+		// authentication type
+		destination = destination.Copy()
+		destination.SetProperty("Authentication", destination.Authentication)
+		for _, rule := range proxyTypeRules[destination.ProxyType] {
+			issues = append(issues, rule(destination)...)
+		}
+		for _, rule := range authenticationRules[destination.Authentication] {
+			issues = append(issues, rule(destination)...)
+		}
+	}
+	for idx := range issues {
+		issues[idx].Destination = destination.Name
+	}
+	return issues
+}
+
+// HasErrors checks if there are issues with error severity
+func HasErrors(issues []Issue) bool {
+	return len(Errors(issues)) > 0
+}
+
+// Errors returns issues with error severity
+func Errors(issues []Issue) []Issue {
+	errors := make([]Issue, 0)
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errors = append(errors, issue)
+		}
+	}
+	return errors
+}
+
+// Warnings returns issues with warning severity
+func Warnings(issues []Issue) []Issue {
+	warnings := make([]Issue, 0)
+	for _, issue := range issues {
+		if issue.Severity == SeverityWarning {
+			warnings = append(warnings, issue)
+		}
+	}
+	return warnings
+}
+
+func newError(field string, message string, args ...interface{}) Issue {
+	return Issue{Field: field, Severity: SeverityError, Message: fmt.Sprintf(message, args...)}
+}
+
+func newWarning(field string, message string, args ...interface{}) Issue {
+	return Issue{Field: field, Severity: SeverityWarning, Message: fmt.Sprintf(message, args...)}
+}
+
+func checkName(destination models.DestinationConfiguration) []Issue {
+	if destination.Name == "" {
+		return []Issue{newError("Name", "destination name is missing")}
+	}
+	if !namePattern.MatchString(destination.Name) {
+		return []Issue{newError("Name", "destination name %q may contain only letters, digits, '-' and '_' and be up to 200 characters long", destination.Name)}
+	}
+	return nil
+}
+
+func checkType(destination models.DestinationConfiguration) []Issue {
+	if destination.Type == "" {
+		return []Issue{newError("Type", "destination type is missing")}
+	}
+	if !containsString(knownTypes, destination.Type) {
+		return []Issue{newWarning("Type", "unknown destination type %q, expected one of %s", destination.Type, strings.Join(knownTypes, ", "))}
+	}
+	return nil
+}
+
+func checkURL(destination models.DestinationConfiguration) []Issue {
+	if destination.Type != "HTTP" {
+		return nil
+	}
+	if destination.URL == "" {
+		return []Issue{newError("URL", "destination URL is missing")}
+	}
+	if _, err := parseHTTPURL(destination.URL); err != nil {
+		return []Issue{newError("URL", "invalid URL %q: %s", destination.URL, err.Error())}
+	}
+	return nil
+}
+
+func checkProxyType(destination models.DestinationConfiguration) []Issue {
+	if destination.Type != "HTTP" {
+		return nil
+	}
+	if destination.ProxyType == "" {
+		return []Issue{newError("ProxyType", "proxy type is missing")}
+	}
+	if !containsString(knownProxyTypes, destination.ProxyType) {
+		return []Issue{newError("ProxyType", "unknown proxy type %q, expected one of %s", destination.ProxyType, strings.Join(knownProxyTypes, ", "))}
+	}
+	return nil
+}
+
+func checkAuthentication(destination models.DestinationConfiguration) []Issue {
+	if destination.Type != "HTTP" {
+		return nil
+	}
+	if destination.Authentication == "" {
+		return []Issue{newError("Authentication", "authentication type is missing")}
+	}
+	if _, ok := authenticationRules[destination.Authentication]; !ok {
+		return []Issue{newWarning("Authentication", "unknown authentication type %q", destination.Authentication)}
+	}
+	return nil
+}
+
+// checkRedactedValues checks that no attribute or property contains
+// placeholder of redacted secret, e.g. from export without secrets, which
+// would overwrite real secret in destination service
+func checkRedactedValues(destination models.DestinationConfiguration) []Issue {
+	issues := make([]Issue, 0)
+	destinationMap := destination.Map()
+	keys := make([]string, 0, len(destinationMap))
+	for key := range destinationMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if destinationMap[key] == models.RedactedValue {
+			issues = append(issues, newError(key, "value is placeholder %s of redacted secret. Provide real value", models.RedactedValue))
+		}
+	}
+	return issues
+}
+
+// checkOnPremiseURL checks that URL of OnPremise destination points to
+// virtual host of Cloud Connector with explicit port
+func checkOnPremiseURL(destination models.DestinationConfiguration) []Issue {
+	parsedURL, err := parseHTTPURL(destination.URL)
+	if err != nil {
+		return nil
+	}
+	if parsedURL.Port() == "" {
+		return []Issue{newError("URL", "URL of OnPremise destination must contain virtual host and port, e.g. http://virtualhost:44300")}
+	}
+	return nil
+}
+
+func checkInternetURL(destination models.DestinationConfiguration) []Issue {
+	parsedURL, err := parseHTTPURL(destination.URL)
+	if err != nil {
+		return nil
+	}
+	if parsedURL.Scheme == "http" && destination.Authentication != models.NoAuthenticationType {
+		return []Issue{newWarning("URL", "credentials of Internet destination are sent over unencrypted http connection")}
+	}
+	return nil
+}
+
+func checkBasicAuthentication(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.BasicAuthentication)
+	if !ok {
+		return nil
+	}
+	return requireFields(destination, field{"User", auth.User}, field{"Password", auth.Password})
+}
+
+func checkOAuth2ClientCredentials(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.OAuth2ClientCredentials)
+	if !ok {
+		return nil
+	}
+	issues := requireFields(destination, field{"tokenServiceURL", auth.TokenServiceURL}, field{"clientId", auth.ClientID})
+	issues = append(issues, checkTokenServiceURL(auth.TokenServiceURL)...)
+	return append(issues, requireOneOf(destination, field{"clientSecret", auth.ClientSecret}, field{"tokenService.KeyStoreLocation", auth.KeyStoreLocation})...)
+}
+
+func checkOAuth2SAMLBearerAssertion(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.OAuth2SAMLBearerAssertion)
+	if !ok {
+		return nil
+	}
+	issues := requireFields(destination,
+		field{"audience", auth.Audience},
+		field{"clientKey", auth.ClientKey},
+		field{"tokenServiceURL", auth.TokenServiceURL},
+		field{"authnContextClassRef", auth.AuthnContextClassRef})
+	return append(issues, checkTokenServiceURL(auth.TokenServiceURL)...)
+}
+
+func checkOAuth2UserTokenExchange(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.OAuth2UserTokenExchange)
+	if !ok {
+		return nil
+	}
+	issues := requireFields(destination, field{"tokenServiceURL", auth.TokenServiceURL}, field{"clientId", auth.ClientID})
+	issues = append(issues, checkTokenServiceURL(auth.TokenServiceURL)...)
+	return append(issues, requireOneOf(destination, field{"clientSecret", auth.ClientSecret}, field{"tokenService.KeyStoreLocation", auth.KeyStoreLocation})...)
+}
+
+func checkOAuth2JWTBearer(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.OAuth2JWTBearer)
+	if !ok {
+		return nil
+	}
+	issues := requireFields(destination, field{"tokenServiceURL", auth.TokenServiceURL}, field{"clientId", auth.ClientID})
+	issues = append(issues, checkTokenServiceURL(auth.TokenServiceURL)...)
+	return append(issues, requireOneOf(destination, field{"clientSecret", auth.ClientSecret}, field{"tokenService.KeyStoreLocation", auth.KeyStoreLocation})...)
+}
+
+func checkOAuth2Password(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.OAuth2Password)
+	if !ok {
+		return nil
+	}
+	issues := requireFields(destination,
+		field{"tokenServiceURL", auth.TokenServiceURL},
+		field{"clientId", auth.ClientID},
+		field{"clientSecret", auth.ClientSecret},
+		field{"User", auth.User},
+		field{"Password", auth.Password})
+	return append(issues, checkTokenServiceURL(auth.TokenServiceURL)...)
+}
+
+func checkSAMLAssertion(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.SAMLAssertion)
+	if !ok {
+		return nil
+	}
+	return requireFields(destination, field{"audience", auth.Audience}, field{"authnContextClassRef", auth.AuthnContextClassRef})
+}
+
+func checkClientCertificateAuthentication(destination models.DestinationConfiguration) []Issue {
+	auth, ok := destination.Auth.(*models.ClientCertificateAuthentication)
+	if !ok {
+		return nil
+	}
+	return requireFields(destination, field{"KeyStoreLocation", auth.KeyStoreLocation}, field{"KeyStorePassword", auth.KeyStorePassword})
+}
+
+func checkTokenServiceURL(tokenServiceURL string) []Issue {
+	if tokenServiceURL == "" {
+		return nil
+	}
+	if _, err := parseHTTPURL(tokenServiceURL); err != nil {
+		return []Issue{newError("tokenServiceURL", "invalid URL %q: %s", tokenServiceURL, err.Error())}
+	}
+	return nil
+}
+
+// field attribute of typed destination authentication with its value
+type field struct {
+	name  string
+	value string
+}
+
+// requireFields checks that attributes of authentication are set
+func requireFields(destination models.DestinationConfiguration, fields ...field) []Issue {
+	issues := make([]Issue, 0)
+	for _, field := range fields {
+		if field.value == "" {
+			issues = append(issues, newError(field.name, "%s is required for %s authentication", field.name, destination.Authentication))
+		}
+	}
+	return issues
+}
+
+// requireOneOf checks that at least one of attributes of authentication is set
+func requireOneOf(destination models.DestinationConfiguration, fields ...field) []Issue {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.value != "" {
+			return nil
+		}
+		names = append(names, field.name)
+	}
+	return []Issue{newError(names[0], "one of %s is required for %s authentication", strings.Join(names, ", "), destination.Authentication)}
+}
+
+// requireProxyType returns rule, which checks proxy type of destination
+func requireProxyType(proxyType string) Rule {
+	return func(destination models.DestinationConfiguration) []Issue {
+		if destination.ProxyType != proxyType {
+			return []Issue{newError("ProxyType", "%s authentication requires %s proxy type", destination.Authentication, proxyType)}
+		}
+		return nil
+	}
+}
+
+// parseHTTPURL parses absolute http(s) URL
+func parseHTTPURL(rawURL string) (*url.URL, error) {
+	if strings.ContainsAny(rawURL, " \t\r\n") {
+		return nil, fmt.Errorf("URL contains whitespace")
+	}
+	parsedURL, err := url.Parse(rawURL)
+	if urlError, ok := err.(*url.Error); ok {
+		return nil, urlError.Err
+	} else if err != nil {
+		return nil, err
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return nil, fmt.Errorf("scheme must be http or https")
+	}
+	if parsedURL.Hostname() == "" {
+		return nil, fmt.Errorf("host is missing")
+	}
+	return parsedURL, nil
+}
+
+func containsString(collection []string, value string) bool {
+	for _, currentValue := range collection {
+		if currentValue == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,257 @@
+package validation
+
+import (
+	"cf-cloud-connector/clients/models"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// httpDestination returns valid HTTP destination without authentication,
+// which attributes are overridden by attributes. Attributes with empty
+// values are removed
+func httpDestination(t *testing.T, attributes map[string]string) models.DestinationConfiguration {
+	values := map[string]string{
+		"Name":           "dest",
+		"Type":           "HTTP",
+		"URL":            "https://example.com",
+		"ProxyType":      "Internet",
+		"Authentication": "NoAuthentication",
+	}
+	for key, value := range attributes {
+		if value == "" {
+			delete(values, key)
+			continue
+		}
+		values[key] = value
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("could not marshal destination: %s", err)
+	}
+	var destination models.DestinationConfiguration
+	if err := json.Unmarshal(data, &destination); err != nil {
+		t.Fatalf("could not unmarshal destination: %s", err)
+	}
+	return destination
+}
+
+// issueFields returns fields of issues
+func issueFields(issues []Issue) []string {
+	fields := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		fields = append(fields, issue.Field)
+	}
+	return fields
+}
+
+func TestValidate(t *testing.T) {
+	oauth := map[string]string{
+		"tokenServiceURL": "https://example.com/oauth/token",
+		"clientId":        "client",
+	}
+	with := func(base map[string]string, attributes map[string]string) map[string]string {
+		result := make(map[string]string)
+		for key, value := range base {
+			result[key] = value
+		}
+		for key, value := range attributes {
+			result[key] = value
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name       string
+		attributes map[string]string
+		errors     []string
+		warnings   []string
+	}{
+		{
+			name: "no authentication",
+		},
+		{
+			name:       "basic authentication",
+			attributes: map[string]string{"Authentication": models.BasicAuthenticationType, "User": "user", "Password": "secret"},
+		},
+		{
+			name:       "basic authentication without password",
+			attributes: map[string]string{"Authentication": models.BasicAuthenticationType, "User": "user"},
+			errors:     []string{"Password"},
+		},
+		{
+			name:       "principal propagation",
+			attributes: map[string]string{"Authentication": models.PrincipalPropagationType, "ProxyType": "OnPremise", "URL": "http://virtualhost:44300"},
+		},
+		{
+			name:       "principal propagation via Internet",
+			attributes: map[string]string{"Authentication": models.PrincipalPropagationType},
+			errors:     []string{"ProxyType"},
+		},
+		{
+			name:       "OAuth2 client credentials with client secret",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2ClientCredentialsType, "clientSecret": "secret"}),
+		},
+		{
+			name:       "OAuth2 client credentials with key store",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2ClientCredentialsType, "tokenService.KeyStoreLocation": "client.p12"}),
+		},
+		{
+			name:       "OAuth2 client credentials without client secret",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2ClientCredentialsType}),
+			errors:     []string{"clientSecret"},
+		},
+		{
+			name:       "OAuth2 client credentials with invalid token service URL",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2ClientCredentialsType, "clientSecret": "secret", "tokenServiceURL": "token"}),
+			errors:     []string{"tokenServiceURL"},
+		},
+		{
+			name: "OAuth2 SAML bearer assertion",
+			attributes: map[string]string{
+				"Authentication":       models.OAuth2SAMLBearerAssertionType,
+				"audience":             "audience",
+				"clientKey":            "client",
+				"tokenServiceURL":      "https://example.com/oauth/token",
+				"authnContextClassRef": "urn:oasis:names:tc:SAML:2.0:ac:classes:PreviousSession",
+			},
+		},
+		{
+			name:       "OAuth2 SAML bearer assertion without attributes",
+			attributes: map[string]string{"Authentication": models.OAuth2SAMLBearerAssertionType},
+			errors:     []string{"audience", "clientKey", "tokenServiceURL", "authnContextClassRef"},
+		},
+		{
+			name:       "OAuth2 user token exchange with client secret",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2UserTokenExchangeType, "clientSecret": "secret"}),
+		},
+		{
+			name:       "OAuth2 user token exchange with key store",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2UserTokenExchangeType, "tokenService.KeyStoreLocation": "client.p12"}),
+		},
+		{
+			name:       "OAuth2 user token exchange without client secret",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2UserTokenExchangeType}),
+			errors:     []string{"clientSecret"},
+		},
+		{
+			name:       "OAuth2 JWT bearer with client secret",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2JWTBearerType, "clientSecret": "secret"}),
+		},
+		{
+			name:       "OAuth2 JWT bearer with key store",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2JWTBearerType, "tokenService.KeyStoreLocation": "client.p12"}),
+		},
+		{
+			name:       "OAuth2 JWT bearer without client ID and secret",
+			attributes: map[string]string{"Authentication": models.OAuth2JWTBearerType, "tokenServiceURL": "https://example.com/oauth/token"},
+			errors:     []string{"clientId", "clientSecret"},
+		},
+		{
+			name:       "OAuth2 password",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2PasswordType, "clientSecret": "secret", "User": "user", "Password": "secret"}),
+		},
+		{
+			name:       "OAuth2 password without user",
+			attributes: with(oauth, map[string]string{"Authentication": models.OAuth2PasswordType, "clientSecret": "secret"}),
+			errors:     []string{"User", "Password"},
+		},
+		{
+			name:       "SAML assertion",
+			attributes: map[string]string{"Authentication": models.SAMLAssertionType, "audience": "audience", "authnContextClassRef": "urn:oasis:names:tc:SAML:2.0:ac:classes:PreviousSession"},
+		},
+		{
+			name:       "SAML assertion without audience",
+			attributes: map[string]string{"Authentication": models.SAMLAssertionType, "authnContextClassRef": "urn:oasis:names:tc:SAML:2.0:ac:classes:PreviousSession"},
+			errors:     []string{"audience"},
+		},
+		{
+			name:       "client certificate authentication",
+			attributes: map[string]string{"Authentication": models.ClientCertificateAuthenticationType, "KeyStoreLocation": "client.p12", "KeyStorePassword": "secret"},
+		},
+		{
+			name:       "client certificate authentication without password",
+			attributes: map[string]string{"Authentication": models.ClientCertificateAuthenticationType, "KeyStoreLocation": "client.p12"},
+			errors:     []string{"KeyStorePassword"},
+		},
+		{
+			name:       "unknown authentication type",
+			attributes: map[string]string{"Authentication": "Custom"},
+			warnings:   []string{"Authentication"},
+		},
+		{
+			name:       "missing authentication type",
+			attributes: map[string]string{"Authentication": ""},
+			errors:     []string{"Authentication"},
+		},
+		{
+			name:       "credentials over http",
+			attributes: map[string]string{"Authentication": models.BasicAuthenticationType, "User": "user", "Password": "secret", "URL": "http://example.com"},
+			warnings:   []string{"URL"},
+		},
+		{
+			name:       "unknown destination type",
+			attributes: map[string]string{"Type": "FTP"},
+			warnings:   []string{"Type"},
+		},
+		{
+			name:       "redacted secret",
+			attributes: map[string]string{"Authentication": models.BasicAuthenticationType, "User": "user", "Password": models.RedactedValue},
+			errors:     []string{"Password"},
+		},
+		{
+			name:       "errors and warnings",
+			attributes: map[string]string{"Name": "dest?", "Type": "HTTP", "URL": "http://example.com", "Authentication": models.BasicAuthenticationType},
+			errors:     []string{"Name", "User", "Password"},
+			warnings:   []string{"URL"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			destination := httpDestination(t, tc.attributes)
+			issues := Validate(destination)
+			for _, issue := range issues {
+				if issue.Destination != destination.Name {
+					t.Errorf("issue %v refers to destination %q, expected %q", issue, issue.Destination, destination.Name)
+				}
+			}
+
+			errors, warnings := issueFields(Errors(issues)), issueFields(Warnings(issues))
+			expectedErrors, expectedWarnings := tc.errors, tc.warnings
+			if expectedErrors == nil {
+				expectedErrors = []string{}
+			}
+			if expectedWarnings == nil {
+				expectedWarnings = []string{}
+			}
+			if !reflect.DeepEqual(errors, expectedErrors) {
+				t.Errorf("errors are %v, expected %v", Errors(issues), expectedErrors)
+			}
+			if !reflect.DeepEqual(warnings, expectedWarnings) {
+				t.Errorf("warnings are %v, expected %v", Warnings(issues), expectedWarnings)
+			}
+			if HasErrors(issues) != (len(expectedErrors) > 0) {
+				t.Errorf("HasErrors is %t, expected %t", HasErrors(issues), len(expectedErrors) > 0)
+			}
+		})
+	}
+}
+
+func TestValidateUntypedAuthentication(t *testing.T) {
+	destination := models.DestinationConfiguration{
+		Name:           "dest",
+		Type:           "HTTP",
+		URL:            "https://example.com",
+		ProxyType:      "Internet",
+		Authentication: models.BasicAuthenticationType,
+		Properties:     map[string]string{"User": "user"},
+	}
+	issues := Validate(destination)
+	if fields := issueFields(issues); !reflect.DeepEqual(fields, []string{"Password"}) {
+		t.Errorf("issues are %v, expected issue of Password", issues)
+	}
+	if destination.Auth != nil || len(destination.Properties) != 1 {
+		t.Errorf("destination is changed by validation: %#v", destination)
+	}
+}